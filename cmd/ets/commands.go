@@ -0,0 +1,293 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/vapourismo/ets-go/diff"
+	"github.com/vapourismo/ets-go/ets"
+	"github.com/vapourismo/ets-go/lint"
+)
+
+func runInfo(args []string) error {
+	opts, err := parseOptions("info", args, 1)
+	if err != nil {
+		return err
+	}
+
+	ex, err := loadExport(opts.files[0], opts.password)
+	if err != nil {
+		return err
+	}
+
+	t := newTable("Project", "Name", "Installation", "Devices", "GroupAddresses")
+
+	for _, proj := range ex.projects {
+		info := ex.projectInfo(proj)
+
+		for n := range proj.Installations {
+			inst := &proj.Installations[n]
+
+			numDevices := 0
+			inst.WalkDevices(func(*ets.Area, *ets.Line, *ets.DeviceInstance) {
+				numDevices++
+			})
+
+			numAddresses := 0
+			inst.WalkGroupAddresses(func([]*ets.GroupRange, *ets.GroupAddress) {
+				numAddresses++
+			})
+
+			t.add(proj.ID, info.Name, inst.Name, numDevices, numAddresses)
+		}
+	}
+
+	if err := t.write(os.Stdout, opts.json); err != nil {
+		return err
+	}
+
+	if opts.json {
+		return nil
+	}
+
+	fmt.Printf("\n%d manufacturer data file(s)\n", len(ex.manufacturers))
+	return nil
+}
+
+func runTopology(args []string) error {
+	opts, err := parseOptions("topology", args, 1)
+	if err != nil {
+		return err
+	}
+
+	ex, err := loadExport(opts.files[0], opts.password)
+	if err != nil {
+		return err
+	}
+
+	t := newTable("Address", "Kind", "Name", "Medium", "ID")
+
+	for _, proj := range ex.projects {
+		for _, inst := range proj.Installations {
+			for _, area := range inst.Topology {
+				t.add(area.Address, "area", area.Name, "", area.ID)
+
+				for _, line := range area.Lines {
+					medium := ex.mediumName(line.MediumType)
+					t.add(fmt.Sprintf("%d.%d", area.Address, line.Address), "line", line.Name, medium, line.ID)
+
+					for _, device := range line.Devices {
+						address := "-"
+						if device.HasAddress {
+							address = ets.DeviceAddr(&area, &line, &device).String()
+						}
+
+						kind := "device"
+						if device.IsCoupler() {
+							kind = "coupler"
+						}
+
+						t.add(address, kind, device.Name, medium, device.ID)
+					}
+				}
+			}
+		}
+	}
+
+	return t.write(os.Stdout, opts.json)
+}
+
+func runGroups(args []string) error {
+	opts, err := parseOptions("groups", args, 1)
+	if err != nil {
+		return err
+	}
+
+	ex, err := loadExport(opts.files[0], opts.password)
+	if err != nil {
+		return err
+	}
+
+	t := newTable("Address", "Name", "Range", "ID")
+
+	for _, proj := range ex.projects {
+		info := ex.projectInfo(proj)
+
+		for n := range proj.Installations {
+			proj.Installations[n].WalkGroupAddresses(func(ranges []*ets.GroupRange, addr *ets.GroupAddress) {
+				names := make([]string, len(ranges))
+				for m, gr := range ranges {
+					names[m] = gr.Name
+				}
+
+				t.add(info.FormatGroupAddr(addr.Address), addr.Name, strings.Join(names, " / "), addr.ID)
+			})
+		}
+	}
+
+	return t.write(os.Stdout, opts.json)
+}
+
+func runDevices(args []string) error {
+	opts, err := parseOptions("devices", args, 1)
+	if err != nil {
+		return err
+	}
+
+	ex, err := loadExport(opts.files[0], opts.password)
+	if err != nil {
+		return err
+	}
+
+	resolver := ets.NewComObjectResolver(ex.manufacturers)
+	t := newTable("Device", "Name", "Object", "DatapointType", "Flags", "GroupAddresses")
+
+	for _, proj := range ex.projects {
+		info := ex.projectInfo(proj)
+		idx := ets.NewGroupAddressIndex(proj)
+
+		for n := range proj.Installations {
+			proj.Installations[n].WalkDevices(func(area *ets.Area, line *ets.Line, device *ets.DeviceInstance) {
+				address := "-"
+				if device.HasAddress {
+					address = ets.DeviceAddr(area, line, device).String()
+				}
+
+				for _, comObj := range resolver.ResolveDevice(device) {
+					var addresses []string
+					for _, conn := range comObj.Connectors {
+						if addr := idx.GroupAddress(conn.RefID); addr != nil {
+							addresses = append(addresses, info.FormatGroupAddr(addr.Address))
+						} else {
+							addresses = append(addresses, string(conn.RefID))
+						}
+					}
+
+					text := comObj.Text
+					if text == "" {
+						text = string(comObj.Instance.RefID)
+					}
+
+					t.add(
+						address,
+						device.Name,
+						text,
+						comObj.DatapointType,
+						formatFlags(&comObj),
+						strings.Join(addresses, " "),
+					)
+				}
+			})
+		}
+	}
+
+	return t.write(os.Stdout, opts.json)
+}
+
+// formatFlags presents the flags of a communication object the way ETS does.
+func formatFlags(comObj *ets.EffectiveComObject) string {
+	if !comObj.Resolved() {
+		return "?"
+	}
+
+	flags := []struct {
+		enabled bool
+		letter  string
+	}{
+		{comObj.CommunicationFlag, "C"},
+		{comObj.ReadFlag, "R"},
+		{comObj.WriteFlag, "W"},
+		{comObj.TransmitFlag, "T"},
+		{comObj.UpdateFlag, "U"},
+		{comObj.ReadOnInitFlag, "I"},
+	}
+
+	result := ""
+	for _, flag := range flags {
+		if flag.enabled {
+			result += flag.letter
+		} else {
+			result += "-"
+		}
+	}
+
+	return result
+}
+
+func runPrograms(args []string) error {
+	opts, err := parseOptions("programs", args, 1)
+	if err != nil {
+		return err
+	}
+
+	ex, err := loadExport(opts.files[0], opts.password)
+	if err != nil {
+		return err
+	}
+
+	t := newTable("Manufacturer", "Program", "Name", "Version", "Objects", "ObjectRefs")
+
+	for _, md := range ex.manufacturers {
+		for _, prog := range md.Programs {
+			t.add(ex.manufacturerName(md.Manufacturer), prog.ID, prog.Name, prog.Version, len(prog.Objects), len(prog.ObjectRefs))
+		}
+	}
+
+	return t.write(os.Stdout, opts.json)
+}
+
+func runLint(args []string) error {
+	opts, err := parseOptions("lint", args, 1)
+	if err != nil {
+		return err
+	}
+
+	ex, err := loadExport(opts.files[0], opts.password)
+	if err != nil {
+		return err
+	}
+
+	t := newTable("Severity", "Rule", "Message", "Elements")
+
+	for _, proj := range ex.projects {
+		for _, finding := range lint.Lint(proj, ex.manufacturers, ex.projectInfo(proj).GroupAddressStyle) {
+			t.add(finding.Severity, finding.Rule, finding.Message, strings.Join(finding.Elements, " "))
+		}
+	}
+
+	return t.write(os.Stdout, opts.json)
+}
+
+func runDiff(args []string) error {
+	opts, err := parseOptions("diff", args, 2)
+	if err != nil {
+		return err
+	}
+
+	oldExport, err := loadExport(opts.files[0], opts.password)
+	if err != nil {
+		return err
+	}
+
+	newExport, err := loadExport(opts.files[1], opts.password)
+	if err != nil {
+		return err
+	}
+
+	if len(oldExport.projects) != 1 || len(newExport.projects) != 1 {
+		return fmt.Errorf("Command 'diff' expects exactly one project per export")
+	}
+
+	newProj := newExport.projects[0]
+	d := diff.Compare(oldExport.projects[0], newProj, newExport.projectInfo(newProj).GroupAddressStyle)
+
+	if opts.json {
+		return d.WriteJSON(os.Stdout)
+	}
+
+	return d.WriteText(os.Stdout)
+}
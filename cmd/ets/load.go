@@ -0,0 +1,225 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/vapourismo/ets-go/ets"
+)
+
+// options are the flags that are common to all commands.
+type options struct {
+	password string
+	json     bool
+	files    []string
+}
+
+// parseOptions parses the flags of a command which expects the given number of files.
+func parseOptions(name string, args []string, numFiles int) (*options, error) {
+	opts := &options{}
+
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.StringVar(&opts.password, "password", "", "password of protected projects")
+	flags.BoolVar(&opts.json, "json", false, "print JSON instead of tables")
+
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+
+	opts.files = flags.Args()
+	if len(opts.files) != numFiles {
+		return nil, fmt.Errorf("Command '%s' expects %d file(s)", name, numFiles)
+	}
+
+	return opts, nil
+}
+
+// export contains the decoded contents of an export archive.
+type export struct {
+	infos         []*ets.ProjectInfo
+	projects      []*ets.Project
+	manufacturers []*ets.ManufacturerData
+	masterData    *ets.MasterData
+}
+
+// manufacturerName returns the name of the manufacturer if the export contains master data.
+func (ex *export) manufacturerName(id ets.ManufacturerID) string {
+	if ex.masterData == nil {
+		return string(id)
+	}
+
+	return ex.masterData.ManufacturerName(id)
+}
+
+// mediumName returns the name of the medium type if the export contains master data.
+func (ex *export) mediumName(id ets.MediumTypeID) string {
+	if ex.masterData == nil {
+		return string(id)
+	}
+
+	if mt := ex.masterData.MediumType(id); mt != nil {
+		return mt.Name
+	}
+
+	return string(id)
+}
+
+// projectInfo returns the project information belonging to the project.
+func (ex *export) projectInfo(proj *ets.Project) *ets.ProjectInfo {
+	for _, info := range ex.infos {
+		if info.ID == proj.ID {
+			return info
+		}
+	}
+
+	return &ets.ProjectInfo{ID: proj.ID}
+}
+
+func loadExport(path, password string) (*export, error) {
+	archive, err := ets.OpenExportArchiveWithPassword(path, password)
+	if err != nil {
+		return nil, err
+	}
+	defer archive.Close()
+
+	ex := &export{}
+
+	for n := range archive.ProjectFiles {
+		projFile := &archive.ProjectFiles[n]
+
+		info, err := projFile.Decode()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", projFile.Name, err)
+		}
+
+		ex.infos = append(ex.infos, info)
+
+		for m := range projFile.InstallationFiles {
+			instFile := &projFile.InstallationFiles[m]
+
+			proj, err := instFile.Decode()
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", instFile.Name, err)
+			}
+
+			ex.projects = append(ex.projects, proj)
+		}
+	}
+
+	for _, manuFiles := range [][]ets.ManufacturerFile{
+		archive.ManufacturerFiles, archive.HardwareFiles, archive.CatalogFiles,
+	} {
+		for n := range manuFiles {
+			manuFile := &manuFiles[n]
+
+			md, err := manuFile.Decode()
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", manuFile.Name, err)
+			}
+
+			ex.manufacturers = append(ex.manufacturers, md)
+		}
+	}
+
+	if archive.MasterDataFile != nil {
+		md, err := archive.MasterDataFile.Decode()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", archive.MasterDataFile.Name, err)
+		}
+
+		ex.masterData = md
+	}
+
+	return ex, nil
+}
+
+// table is a list of rows that can be printed as a table or as JSON.
+type table struct {
+	columns []string
+	rows    [][]string
+}
+
+func newTable(columns ...string) *table {
+	return &table{columns: columns}
+}
+
+func (t *table) add(values ...interface{}) {
+	row := make([]string, len(values))
+	for n, value := range values {
+		row[n] = fmt.Sprint(value)
+	}
+
+	t.rows = append(t.rows, row)
+}
+
+func (t *table) write(w io.Writer, asJSON bool) error {
+	if asJSON {
+		records := make([]record, len(t.rows))
+		for n, row := range t.rows {
+			records[n] = record{columns: t.columns, values: row}
+		}
+
+		return writeJSON(w, records)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(t.columns, "\t"))
+
+	for _, row := range t.rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+
+	return tw.Flush()
+}
+
+// record is a row of a table which is encoded as a JSON object whose keys appear in the order of the
+// columns.
+type record struct {
+	columns []string
+	values  []string
+}
+
+func (r record) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+
+	buf.WriteByte('{')
+
+	for n, column := range r.columns {
+		if n > 0 {
+			buf.WriteByte(',')
+		}
+
+		if err := enc.Encode(column); err != nil {
+			return nil, err
+		}
+
+		buf.WriteByte(':')
+
+		if err := enc.Encode(r.values[n]); err != nil {
+			return nil, err
+		}
+	}
+
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+func writeJSON(w io.Writer, value interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+
+	return enc.Encode(value)
+}
@@ -0,0 +1,81 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+/*
+Command ets inspects ETS exports (.knxproj and .knxprod files).
+
+Usage:
+
+	ets <command> [flags] <file>
+
+The commands are:
+
+	info       show basic information about the projects and manufacturer data
+	topology   list areas, lines and devices
+	groups     list group addresses
+	devices    list devices and their communication objects
+	programs   list application programs of the manufacturer data
+	lint       check the projects for common mistakes
+	diff       compare the projects of two exports
+
+Each command accepts the flags -password, to open password-protected projects, and -json, to
+print JSON instead of tables.
+*/
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+)
+
+type command struct {
+	description string
+	run         func(args []string) error
+}
+
+var commands = map[string]command{
+	"info":     {"show basic information about the projects and manufacturer data", runInfo},
+	"topology": {"list areas, lines and devices", runTopology},
+	"groups":   {"list group addresses", runGroups},
+	"devices":  {"list devices and their communication objects", runDevices},
+	"programs": {"list application programs of the manufacturer data", runPrograms},
+	"lint":     {"check the projects for common mistakes", runLint},
+	"diff":     {"compare the projects of two exports", runDiff},
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: ets <command> [flags] <file>")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Commands:")
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", name, commands[name].description)
+	}
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Unknown command '%s'\n\n", os.Args[1])
+		usage()
+		os.Exit(2)
+	}
+
+	if err := cmd.run(os.Args[2:]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
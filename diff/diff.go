@@ -0,0 +1,288 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package diff
+
+import (
+	"fmt"
+
+	"github.com/vapourismo/ets-go/ets"
+)
+
+// ChangeKind describes how an element has changed.
+type ChangeKind int
+
+const (
+	// Added elements only exist in the new project.
+	Added ChangeKind = iota
+
+	// Removed elements only exist in the old project.
+	Removed
+
+	// Changed elements exist in both projects but differ.
+	Changed
+)
+
+// String returns the name of the change kind.
+func (k ChangeKind) String() string {
+	switch k {
+	case Added:
+		return "added"
+
+	case Removed:
+		return "removed"
+
+	case Changed:
+		return "changed"
+
+	default:
+		return fmt.Sprintf("kind(%d)", int(k))
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (k ChangeKind) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+// ElementKind is the kind of element that has changed.
+type ElementKind string
+
+// These are the kinds of elements that are compared.
+const (
+	Area         ElementKind = "area"
+	Line         ElementKind = "line"
+	Device       ElementKind = "device"
+	Connection   ElementKind = "connection"
+	GroupRange   ElementKind = "group-range"
+	GroupAddress ElementKind = "group-address"
+)
+
+// elementKinds lists the kinds of elements in the order in which changes are reported.
+var elementKinds = []ElementKind{Area, Line, Device, Connection, GroupRange, GroupAddress}
+
+// FieldChange is a change to a single property of an element.
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// Change is a change to an element.
+type Change struct {
+	Kind    ChangeKind    `json:"kind"`
+	Element ElementKind   `json:"element"`
+	ID      string        `json:"id"`
+	Name    string        `json:"name,omitempty"`
+	Fields  []FieldChange `json:"fields,omitempty"`
+}
+
+// Diff contains the changes between two projects.
+type Diff struct {
+	Changes []Change `json:"changes"`
+}
+
+// Empty determines whether there are no changes.
+func (d *Diff) Empty() bool {
+	return len(d.Changes) == 0
+}
+
+type field struct {
+	name  string
+	value string
+}
+
+type element struct {
+	id     string
+	name   string
+	fields []field
+}
+
+// elements maps each kind of element to the elements of that kind in the order of their
+// appearance in the project.
+type elements map[ElementKind][]element
+
+func (es elements) add(kind ElementKind, id, name string, fields ...field) {
+	es[kind] = append(es[kind], element{id: id, name: name, fields: fields})
+}
+
+func collectGroupRange(es elements, parent string, gr *ets.GroupRange, style ets.GroupAddrStyle) {
+	es.add(
+		GroupRange, string(gr.ID), gr.Name,
+		field{"Name", gr.Name},
+		field{"RangeStart", gr.RangeStart.Format(style)},
+		field{"RangeEnd", gr.RangeEnd.Format(style)},
+		field{"Parent", parent},
+	)
+
+	for _, addr := range gr.Addresses {
+		es.add(
+			GroupAddress, string(addr.ID), addr.Name,
+			field{"Name", addr.Name},
+			field{"Address", addr.Address.Format(style)},
+			field{"Description", addr.Description},
+			field{"DatapointType", addr.DatapointType},
+			field{"Central", fmt.Sprint(addr.Central)},
+			field{"Unfiltered", fmt.Sprint(addr.Unfiltered)},
+			field{"Security", string(addr.Security)},
+			field{"Parent", string(gr.ID)},
+		)
+	}
+
+	for n := range gr.SubRanges {
+		collectGroupRange(es, string(gr.ID), &gr.SubRanges[n], style)
+	}
+}
+
+func connectionID(device *ets.DeviceInstance, comObj *ets.ComObjectInstanceRef, conn ets.Connector) string {
+	return fmt.Sprintf("%s/%s->%s", device.ID, comObj.RefID, conn.RefID)
+}
+
+func collect(proj *ets.Project, style ets.GroupAddrStyle) elements {
+	es := elements{}
+
+	for i := range proj.Installations {
+		inst := &proj.Installations[i]
+
+		for _, area := range inst.Topology {
+			es.add(
+				Area, string(area.ID), area.Name,
+				field{"Name", area.Name},
+				field{"Address", fmt.Sprint(area.Address)},
+			)
+
+			for _, line := range area.Lines {
+				es.add(
+					Line, string(line.ID), line.Name,
+					field{"Name", line.Name},
+					field{"Address", fmt.Sprintf("%d.%d", area.Address, line.Address)},
+					field{"Area", string(area.ID)},
+				)
+			}
+		}
+
+		inst.WalkDevices(func(area *ets.Area, line *ets.Line, device *ets.DeviceInstance) {
+			address := ""
+			if device.HasAddress {
+				address = ets.DeviceAddr(area, line, device).String()
+			}
+
+			es.add(
+				Device, string(device.ID), device.Name,
+				field{"Name", device.Name},
+				field{"Address", address},
+				field{"Line", string(line.ID)},
+			)
+
+			for n := range device.ComObjects {
+				comObj := &device.ComObjects[n]
+
+				for _, conn := range comObj.Connectors {
+					es.add(
+						Connection, connectionID(device, comObj, conn), device.Name,
+						field{"Receive", fmt.Sprint(conn.Receive)},
+					)
+				}
+			}
+		})
+
+		for n := range inst.GroupAddresses {
+			collectGroupRange(es, "", &inst.GroupAddresses[n], style)
+		}
+	}
+
+	return es
+}
+
+func compareFields(oldFields, newFields []field) []FieldChange {
+	var changes []FieldChange
+
+	for n := range oldFields {
+		if n < len(newFields) && oldFields[n].value != newFields[n].value {
+			changes = append(changes, FieldChange{
+				Field: oldFields[n].name,
+				Old:   oldFields[n].value,
+				New:   newFields[n].value,
+			})
+		}
+	}
+
+	return changes
+}
+
+// Compare two projects. Elements are matched by their IDs. Group addresses are formatted using the
+// given style.
+func Compare(oldProj, newProj *ets.Project, style ets.GroupAddrStyle) *Diff {
+	oldElements := collect(oldProj, style)
+	newElements := collect(newProj, style)
+
+	d := &Diff{}
+
+	for _, kind := range elementKinds {
+		newByID := map[string]element{}
+		for _, elem := range newElements[kind] {
+			newByID[elem.id] = elem
+		}
+
+		oldByID := map[string]element{}
+		for _, oldElem := range oldElements[kind] {
+			oldByID[oldElem.id] = oldElem
+
+			newElem, ok := newByID[oldElem.id]
+			if !ok {
+				d.Changes = append(d.Changes, Change{
+					Kind:    Removed,
+					Element: kind,
+					ID:      oldElem.id,
+					Name:    oldElem.name,
+				})
+
+				continue
+			}
+
+			if fields := compareFields(oldElem.fields, newElem.fields); len(fields) > 0 {
+				d.Changes = append(d.Changes, Change{
+					Kind:    Changed,
+					Element: kind,
+					ID:      newElem.id,
+					Name:    newElem.name,
+					Fields:  fields,
+				})
+			}
+		}
+
+		for _, newElem := range newElements[kind] {
+			if _, ok := oldByID[newElem.id]; !ok {
+				d.Changes = append(d.Changes, Change{
+					Kind:    Added,
+					Element: kind,
+					ID:      newElem.id,
+					Name:    newElem.name,
+				})
+			}
+		}
+	}
+
+	return d
+}
+
+// String generates a summary of the change.
+func (c Change) String() string {
+	var prefix string
+	switch c.Kind {
+	case Added:
+		prefix = "+"
+
+	case Removed:
+		prefix = "-"
+
+	default:
+		prefix = "~"
+	}
+
+	if c.Name == "" {
+		return fmt.Sprintf("%s %s %s", prefix, c.Element, c.ID)
+	}
+
+	return fmt.Sprintf("%s %s %s (%s)", prefix, c.Element, c.ID, c.Name)
+}
@@ -0,0 +1,246 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package diff
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/vapourismo/ets-go/ets"
+)
+
+// testProjects returns two revisions of a small project.
+//
+// The new revision adds a line, renames and moves a device, replaces another device, changes one
+// connection, adds another one, resizes the group range and replaces a group address.
+func testProjects() (*ets.Project, *ets.Project) {
+	oldProj := &ets.Project{
+		Installations: []ets.Installation{{
+			Topology: []ets.Area{{
+				ID:      "A-1",
+				Name:    "Area",
+				Address: 1,
+				Lines: []ets.Line{{
+					ID:      "L-1",
+					Name:    "Line",
+					Address: 1,
+					Devices: []ets.DeviceInstance{
+						{
+							ID:         "DI-1",
+							Name:       "Switch",
+							Address:    1,
+							HasAddress: true,
+							ComObjects: []ets.ComObjectInstanceRef{{
+								RefID:      "O-1_R-1",
+								Connectors: []ets.Connector{{RefID: "GA-1"}},
+							}},
+						},
+						{ID: "DI-2", Name: "Dimmer", Address: 2, HasAddress: true},
+					},
+				}},
+			}},
+			GroupAddresses: []ets.GroupRange{{
+				ID:         "GR-1",
+				Name:       "Lights",
+				RangeStart: ets.NewGroupAddr3(1, 0, 0),
+				RangeEnd:   ets.NewGroupAddr3(1, 0, 255),
+				Addresses: []ets.GroupAddress{
+					{ID: "GA-1", Name: "Switch", Address: ets.NewGroupAddr3(1, 0, 1)},
+					{ID: "GA-2", Name: "Dim", Address: ets.NewGroupAddr3(1, 0, 2)},
+				},
+			}},
+		}},
+	}
+
+	newProj := &ets.Project{
+		Installations: []ets.Installation{{
+			Topology: []ets.Area{{
+				ID:      "A-1",
+				Name:    "Area",
+				Address: 1,
+				Lines: []ets.Line{
+					{
+						ID:      "L-1",
+						Name:    "Line",
+						Address: 1,
+						Devices: []ets.DeviceInstance{{
+							ID:         "DI-1",
+							Name:       "Switch actuator",
+							Address:    3,
+							HasAddress: true,
+							ComObjects: []ets.ComObjectInstanceRef{{
+								RefID: "O-1_R-1",
+								Connectors: []ets.Connector{
+									{RefID: "GA-1", Receive: true},
+									{RefID: "GA-3"},
+								},
+							}},
+						}},
+					},
+					{
+						ID:      "L-2",
+						Name:    "Second line",
+						Address: 2,
+						Devices: []ets.DeviceInstance{
+							{ID: "DI-3", Name: "Sensor"},
+						},
+					},
+				},
+			}},
+			GroupAddresses: []ets.GroupRange{{
+				ID:         "GR-1",
+				Name:       "Lights",
+				RangeStart: ets.NewGroupAddr3(1, 0, 0),
+				RangeEnd:   ets.NewGroupAddr3(1, 7, 255),
+				Addresses: []ets.GroupAddress{
+					{
+						ID:            "GA-1",
+						Name:          "Switch",
+						Address:       ets.NewGroupAddr3(1, 0, 1),
+						DatapointType: "DPST-1-1",
+					},
+					{ID: "GA-3", Name: "Status", Address: ets.NewGroupAddr3(1, 0, 3)},
+				},
+			}},
+		}},
+	}
+
+	return oldProj, newProj
+}
+
+func TestCompare(t *testing.T) {
+	oldProj, newProj := testProjects()
+
+	want := []Change{
+		{Kind: Added, Element: Line, ID: "L-2", Name: "Second line"},
+		{
+			Kind:    Changed,
+			Element: Device,
+			ID:      "DI-1",
+			Name:    "Switch actuator",
+			Fields: []FieldChange{
+				{Field: "Name", Old: "Switch", New: "Switch actuator"},
+				{Field: "Address", Old: "1.1.1", New: "1.1.3"},
+			},
+		},
+		{Kind: Removed, Element: Device, ID: "DI-2", Name: "Dimmer"},
+		{Kind: Added, Element: Device, ID: "DI-3", Name: "Sensor"},
+		{
+			Kind:    Changed,
+			Element: Connection,
+			ID:      "DI-1/O-1_R-1->GA-1",
+			Name:    "Switch actuator",
+			Fields:  []FieldChange{{Field: "Receive", Old: "false", New: "true"}},
+		},
+		{Kind: Added, Element: Connection, ID: "DI-1/O-1_R-1->GA-3", Name: "Switch actuator"},
+		{
+			Kind:    Changed,
+			Element: GroupRange,
+			ID:      "GR-1",
+			Name:    "Lights",
+			Fields:  []FieldChange{{Field: "RangeEnd", Old: "1/0/255", New: "1/7/255"}},
+		},
+		{
+			Kind:    Changed,
+			Element: GroupAddress,
+			ID:      "GA-1",
+			Name:    "Switch",
+			Fields:  []FieldChange{{Field: "DatapointType", Old: "", New: "DPST-1-1"}},
+		},
+		{Kind: Removed, Element: GroupAddress, ID: "GA-2", Name: "Dim"},
+		{Kind: Added, Element: GroupAddress, ID: "GA-3", Name: "Status"},
+	}
+
+	d := Compare(oldProj, newProj, ets.GroupAddrStyleThreeLevel)
+	if !reflect.DeepEqual(d.Changes, want) {
+		t.Errorf("Got changes:\n%+v\nwant:\n%+v", d.Changes, want)
+	}
+
+	if d := Compare(oldProj, oldProj, ets.GroupAddrStyleThreeLevel); !d.Empty() {
+		t.Errorf("Comparing a project with itself yields changes %+v", d.Changes)
+	}
+
+	// Changes are reported in the other direction when the projects are swapped.
+	for _, change := range Compare(newProj, oldProj, ets.GroupAddrStyleThreeLevel).Changes {
+		if change.Element == Line && (change.Kind != Removed || change.ID != "L-2") {
+			t.Errorf("Unexpected line change %+v", change)
+		}
+	}
+}
+
+func TestWriteText(t *testing.T) {
+	oldProj, newProj := testProjects()
+
+	var buf bytes.Buffer
+	if err := Compare(oldProj, newProj, ets.GroupAddrStyleThreeLevel).WriteText(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `+ line L-2 (Second line)
+~ device DI-1 (Switch actuator)
+    Name: "Switch" -> "Switch actuator"
+    Address: "1.1.1" -> "1.1.3"
+- device DI-2 (Dimmer)
++ device DI-3 (Sensor)
+~ connection DI-1/O-1_R-1->GA-1 (Switch actuator)
+    Receive: "false" -> "true"
++ connection DI-1/O-1_R-1->GA-3 (Switch actuator)
+~ group-range GR-1 (Lights)
+    RangeEnd: "1/0/255" -> "1/7/255"
+~ group-address GA-1 (Switch)
+    DatapointType: "" -> "DPST-1-1"
+- group-address GA-2 (Dim)
++ group-address GA-3 (Status)
+`
+
+	if got := buf.String(); got != want {
+		t.Errorf("Got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestCompareStyle(t *testing.T) {
+	oldProj, newProj := testProjects()
+
+	for _, change := range Compare(oldProj, newProj, ets.GroupAddrStyleTwoLevel).Changes {
+		if change.Element != GroupRange {
+			continue
+		}
+
+		want := []FieldChange{{Field: "RangeEnd", Old: "1/255", New: "1/2047"}}
+		if !reflect.DeepEqual(change.Fields, want) {
+			t.Errorf("Got group range changes %+v, want %+v", change.Fields, want)
+		}
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	oldProj := &ets.Project{}
+	newProj := &ets.Project{
+		Installations: []ets.Installation{{
+			GroupAddresses: []ets.GroupRange{{ID: "GR-1", Name: "<Lights>"}},
+		}},
+	}
+
+	var buf bytes.Buffer
+	if err := Compare(oldProj, newProj, ets.GroupAddrStyleThreeLevel).WriteJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{
+  "changes": [
+    {
+      "kind": "added",
+      "element": "group-range",
+      "id": "GR-1",
+      "name": "<Lights>"
+    }
+  ]
+}
+`
+
+	if got := buf.String(); got != want {
+		t.Errorf("Got:\n%s\nwant:\n%s", got, want)
+	}
+}
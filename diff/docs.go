@@ -0,0 +1,14 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+/*
+Package diff compares two revisions of an ETS project.
+
+Elements such as devices and group addresses are matched by their IDs. The result lists which
+elements have been added, removed or changed. Group addresses are formatted in the style configured
+for the project.
+
+	d := diff.Compare(oldProj, newProj, info.GroupAddressStyle)
+	d.WriteText(os.Stdout)
+*/
+package diff
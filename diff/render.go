@@ -0,0 +1,37 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// WriteText writes a human-readable representation of the diff. Each change is written on its
+// own line, followed by the changed fields.
+func (d *Diff) WriteText(w io.Writer) error {
+	for _, change := range d.Changes {
+		if _, err := fmt.Fprintln(w, change); err != nil {
+			return err
+		}
+
+		for _, fc := range change.Fields {
+			if _, err := fmt.Fprintf(w, "    %s: %q -> %q\n", fc.Field, fc.Old, fc.New); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// WriteJSON writes the diff as JSON.
+func (d *Diff) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+
+	return enc.Encode(d)
+}
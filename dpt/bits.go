@@ -0,0 +1,137 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package dpt
+
+import "fmt"
+
+// Control is a value of a 2.x datapoint type.
+type Control struct {
+	// Control determines whether the value is applied.
+	Control bool
+	Value   bool
+}
+
+// StepControl is a value of a 3.x datapoint type, e.g. relative dimming.
+type StepControl struct {
+	// Control is the direction, e.g. increase for 3.007 or down for 3.008.
+	Control bool
+
+	// StepCode is the number of intervals as a power of two. 0 means stop.
+	StepCode uint8
+}
+
+// SceneControl is a value of datapoint type 18.001.
+type SceneControl struct {
+	// Learn determines whether the scene is stored instead of activated.
+	Learn bool
+
+	// Scene is the scene number, starting at 0.
+	Scene uint8
+}
+
+var boolCodec = fixedCodec{
+	size: 1,
+	decode: func(data []byte) (interface{}, error) {
+		return data[0]&0x1 != 0, nil
+	},
+	encode: func(value interface{}) ([]byte, error) {
+		b, ok := value.(bool)
+		if !ok {
+			return nil, unexpectedValue(value, "bool")
+		}
+
+		if b {
+			return []byte{1}, nil
+		}
+
+		return []byte{0}, nil
+	},
+}
+
+var controlCodec = fixedCodec{
+	size: 1,
+	decode: func(data []byte) (interface{}, error) {
+		return Control{Control: data[0]&0x2 != 0, Value: data[0]&0x1 != 0}, nil
+	},
+	encode: func(value interface{}) ([]byte, error) {
+		c, ok := value.(Control)
+		if !ok {
+			return nil, unexpectedValue(value, "Control")
+		}
+
+		var b byte
+		if c.Control {
+			b |= 0x2
+		}
+
+		if c.Value {
+			b |= 0x1
+		}
+
+		return []byte{b}, nil
+	},
+}
+
+var stepControlCodec = fixedCodec{
+	size: 1,
+	decode: func(data []byte) (interface{}, error) {
+		return StepControl{Control: data[0]&0x8 != 0, StepCode: data[0] & 0x7}, nil
+	},
+	encode: func(value interface{}) ([]byte, error) {
+		s, ok := value.(StepControl)
+		if !ok {
+			return nil, unexpectedValue(value, "StepControl")
+		}
+
+		if s.StepCode > 7 {
+			return nil, fmt.Errorf("Step code %d is out of range", s.StepCode)
+		}
+
+		b := s.StepCode
+		if s.Control {
+			b |= 0x8
+		}
+
+		return []byte{b}, nil
+	},
+}
+
+var sceneNumberCodec = fixedCodec{
+	size: 1,
+	decode: func(data []byte) (interface{}, error) {
+		return data[0] & 0x3F, nil
+	},
+	encode: func(value interface{}) ([]byte, error) {
+		n, err := toUint(value, 63)
+		if err != nil {
+			return nil, err
+		}
+
+		return []byte{byte(n)}, nil
+	},
+}
+
+var sceneControlCodec = fixedCodec{
+	size: 1,
+	decode: func(data []byte) (interface{}, error) {
+		return SceneControl{Learn: data[0]&0x80 != 0, Scene: data[0] & 0x3F}, nil
+	},
+	encode: func(value interface{}) ([]byte, error) {
+		s, ok := value.(SceneControl)
+		if !ok {
+			return nil, unexpectedValue(value, "SceneControl")
+		}
+
+		if s.Scene > 63 {
+			return nil, fmt.Errorf("Scene %d is out of range", s.Scene)
+		}
+
+		b := s.Scene
+		if s.Learn {
+			b |= 0x80
+		}
+
+		return []byte{b}, nil
+	},
+}
@@ -0,0 +1,124 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package dpt
+
+import (
+	"fmt"
+
+	"github.com/vapourismo/ets-go/ets"
+)
+
+// Codec encodes and decodes the values of a datapoint type.
+type Codec interface {
+	// Decode a payload.
+	Decode(data []byte) (interface{}, error)
+
+	// Encode a value into a payload.
+	Encode(value interface{}) ([]byte, error)
+}
+
+// fixedCodec is a codec for payloads of a fixed size.
+type fixedCodec struct {
+	size   int
+	decode func(data []byte) (interface{}, error)
+	encode func(value interface{}) ([]byte, error)
+}
+
+// Decode implements Codec.
+func (c fixedCodec) Decode(data []byte) (interface{}, error) {
+	if len(data) != c.size {
+		return nil, fmt.Errorf("Expected payload of %d byte(s), got %d", c.size, len(data))
+	}
+
+	return c.decode(data)
+}
+
+// Encode implements Codec.
+func (c fixedCodec) Encode(value interface{}) ([]byte, error) {
+	return c.encode(value)
+}
+
+// codecs maps datapoint types onto their codecs. Main types are used if a sub type has no codec of
+// its own.
+var codecs = map[ets.DatapointType]Codec{
+	ets.NewMainDatapointType(1):    boolCodec,
+	ets.NewMainDatapointType(2):    controlCodec,
+	ets.NewMainDatapointType(3):    stepControlCodec,
+	ets.NewMainDatapointType(4):    charCodec,
+	ets.NewDatapointType(4, 1):     charCodec,
+	ets.NewDatapointType(4, 2):     latin1CharCodec,
+	ets.NewMainDatapointType(5):    uint8Codec,
+	ets.NewDatapointType(5, 1):     scaledCodec(100),
+	ets.NewDatapointType(5, 3):     scaledCodec(360),
+	ets.NewMainDatapointType(6):    int8Codec,
+	ets.NewMainDatapointType(7):    uint16Codec,
+	ets.NewMainDatapointType(8):    int16Codec,
+	ets.NewMainDatapointType(9):    float16Codec,
+	ets.NewMainDatapointType(10):   timeOfDayCodec,
+	ets.NewMainDatapointType(11):   dateCodec,
+	ets.NewMainDatapointType(12):   uint32Codec,
+	ets.NewMainDatapointType(13):   int32Codec,
+	ets.NewMainDatapointType(14):   float32Codec,
+	ets.NewMainDatapointType(16):   stringCodec,
+	ets.NewDatapointType(16, 0):    stringCodec,
+	ets.NewDatapointType(16, 1):    latin1StringCodec,
+	ets.NewMainDatapointType(17):   sceneNumberCodec,
+	ets.NewMainDatapointType(18):   sceneControlCodec,
+	ets.NewMainDatapointType(19):   dateTimeCodec,
+	ets.NewMainDatapointType(20):   uint8Codec,
+	ets.NewMainDatapointType(29):   int64Codec,
+	ets.NewDatapointType(232, 600): rgbCodec,
+}
+
+// Lookup finds the codec for the given datapoint type. If the sub type has no codec of its own,
+// the codec of the main type is used.
+func Lookup(dpt ets.DatapointType) (Codec, bool) {
+	if codec, ok := codecs[dpt]; ok {
+		return codec, true
+	}
+
+	codec, ok := codecs[dpt.MainType()]
+	return codec, ok
+}
+
+// LookupString finds the codec for the first supported datapoint type in a DatapointType
+// attribute, e.g. "DPST-9-1 DPT-9".
+func LookupString(s string) (Codec, ets.DatapointType, error) {
+	dpts, err := ets.ParseDatapointTypes(s)
+	if err != nil {
+		return nil, ets.DatapointType{}, err
+	}
+
+	for _, dpt := range dpts {
+		if codec, ok := Lookup(dpt); ok {
+			return codec, dpt, nil
+		}
+	}
+
+	return nil, ets.DatapointType{}, fmt.Errorf("Unsupported datapoint type '%s'", s)
+}
+
+// Decode a payload of the given datapoint type.
+func Decode(dpt ets.DatapointType, data []byte) (interface{}, error) {
+	codec, ok := Lookup(dpt)
+	if !ok {
+		return nil, fmt.Errorf("Unsupported datapoint type %v", dpt)
+	}
+
+	return codec.Decode(data)
+}
+
+// Encode a value of the given datapoint type.
+func Encode(dpt ets.DatapointType, value interface{}) ([]byte, error) {
+	codec, ok := Lookup(dpt)
+	if !ok {
+		return nil, fmt.Errorf("Unsupported datapoint type %v", dpt)
+	}
+
+	return codec.Encode(value)
+}
+
+func unexpectedValue(value interface{}, expected string) error {
+	return fmt.Errorf("Expected %s, got %T", expected, value)
+}
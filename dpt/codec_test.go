@@ -0,0 +1,177 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package dpt
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/vapourismo/ets-go/ets"
+)
+
+func mustParse(t *testing.T, s string) ets.DatapointType {
+	dpt, err := ets.ParseDatapointType(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return dpt
+}
+
+// equalValues compares decoded values. Floating-point numbers only need to be close.
+func equalValues(a, b interface{}) bool {
+	x, okX := a.(float64)
+	y, okY := b.(float64)
+	if okX && okY {
+		return math.Abs(x-y) < 1e-6
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+// codecTests contains values and their payloads. Each value must encode to its payload and the
+// payload must decode to the value.
+var codecTests = []struct {
+	dpt     string
+	value   interface{}
+	payload []byte
+}{
+	{"1.001", true, []byte{0x01}},
+	{"1.001", false, []byte{0x00}},
+	{"2.001", Control{Control: true, Value: false}, []byte{0x02}},
+	{"3.007", StepControl{Control: true, StepCode: 5}, []byte{0x0D}},
+	{"5.001", 100.0, []byte{0xFF}},
+	{"5.001", 0.0, []byte{0x00}},
+	{"5.010", uint8(42), []byte{0x2A}},
+	{"6.001", int8(-1), []byte{0xFF}},
+	{"7.001", uint16(0xBEEF), []byte{0xBE, 0xEF}},
+	{"8.001", int16(-2), []byte{0xFF, 0xFE}},
+
+	// 9.x: 0.01 * mantissa * 2^exponent.
+	{"9.001", 21.3, []byte{0x0C, 0x29}},
+	{"9.001", 0.0, []byte{0x00, 0x00}},
+	{"9.001", -30.0, []byte{0x8A, 0x24}},
+	{"9.001", 20.47, []byte{0x07, 0xFF}},
+	{"9.001", -20.48, []byte{0x80, 0x00}},
+	{"9.001", 670433.28, []byte{0x7F, 0xFE}},
+	{"9.001", -671088.64, []byte{0xF8, 0x00}},
+
+	{"10.001", TimeOfDay{Weekday: 1, Hour: 13, Minute: 45, Second: 30}, []byte{0x2D, 0x2D, 0x1E}},
+	{"10.001", TimeOfDay{Weekday: 7, Hour: 23, Minute: 59, Second: 59}, []byte{0xF7, 0x3B, 0x3B}},
+	{"10.001", TimeOfDay{Hour: 0, Minute: 0, Second: 0}, []byte{0x00, 0x00, 0x00}},
+	{"11.001", Date{Year: 2024, Month: 2, Day: 29}, []byte{0x1D, 0x02, 0x18}},
+	{"11.001", Date{Year: 1990, Month: 1, Day: 1}, []byte{0x01, 0x01, 0x5A}},
+	{"11.001", Date{Year: 2089, Month: 12, Day: 31}, []byte{0x1F, 0x0C, 0x59}},
+	{"12.001", uint32(0xDEADBEEF), []byte{0xDE, 0xAD, 0xBE, 0xEF}},
+	{"13.001", int32(-1), []byte{0xFF, 0xFF, 0xFF, 0xFF}},
+	{"14.056", 1.5, []byte{0x3F, 0xC0, 0x00, 0x00}},
+	{"17.001", uint8(63), []byte{0x3F}},
+	{"18.001", SceneControl{Learn: true, Scene: 5}, []byte{0x85}},
+
+	// 19.x: year since 1900, month, day, weekday and hour, minute, second, flags and quality.
+	{
+		"19.001",
+		DateTime{
+			Year: 2023, Month: 6, Day: 15, Weekday: 4, Hour: 14, Minute: 30, Second: 0,
+			WorkingDay: true, SummerTime: true, ExternalSync: true,
+		},
+		[]byte{0x7B, 0x06, 0x0F, 0x8E, 0x1E, 0x00, 0x41, 0x80},
+	},
+	{
+		"19.001",
+		DateTime{Year: 2155, Month: 12, Day: 31, Hour: 24, Fault: true, NoYear: true, NoWeekday: true},
+		[]byte{0xFF, 0x0C, 0x1F, 0x18, 0x00, 0x00, 0x94, 0x00},
+	},
+	{"19.001", DateTime{Year: 1900, NoDate: true, NoTime: true}, []byte{0, 0, 0, 0, 0, 0, 0x0A, 0}},
+
+	{"29.010", int64(-5), []byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFB}},
+	{"232.600", RGB{R: 0x12, G: 0x34, B: 0x56}, []byte{0x12, 0x34, 0x56}},
+}
+
+func TestEncode(t *testing.T) {
+	for _, test := range codecTests {
+		payload, err := Encode(mustParse(t, test.dpt), test.value)
+		if err != nil {
+			t.Errorf("Encode(%s, %v): %v", test.dpt, test.value, err)
+			continue
+		}
+
+		if !bytes.Equal(payload, test.payload) {
+			t.Errorf("Encode(%s, %v) = % X, want % X", test.dpt, test.value, payload, test.payload)
+		}
+	}
+}
+
+func TestDecode(t *testing.T) {
+	for _, test := range codecTests {
+		value, err := Decode(mustParse(t, test.dpt), test.payload)
+		if err != nil {
+			t.Errorf("Decode(%s, % X): %v", test.dpt, test.payload, err)
+			continue
+		}
+
+		if !equalValues(value, test.value) {
+			t.Errorf("Decode(%s, % X) = %#v, want %#v", test.dpt, test.payload, value, test.value)
+		}
+	}
+}
+
+func TestEncodeOutOfRange(t *testing.T) {
+	tests := []struct {
+		dpt   string
+		value interface{}
+	}{
+		// 670760.96 would need the mantissa 2047 with the exponent 15, which is the invalid value
+		// 0x7FFF.
+		{"9.001", 670760.96},
+		{"9.001", 670760.97},
+		{"9.001", -671088.65},
+		{"5.001", 100.5},
+		{"10.001", TimeOfDay{Hour: 24}},
+		{"11.001", Date{Year: 1989, Month: 1, Day: 1}},
+		{"11.001", Date{Year: 2090, Month: 1, Day: 1}},
+		{"19.001", DateTime{Year: 1899}},
+		{"19.001", DateTime{Year: 2156}},
+		{"1.001", 1},
+	}
+
+	for _, test := range tests {
+		if payload, err := Encode(mustParse(t, test.dpt), test.value); err == nil {
+			t.Errorf("Encode(%s, %v) = % X, want an error", test.dpt, test.value, payload)
+		}
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	tests := []struct {
+		dpt     string
+		payload []byte
+	}{
+		{"9.001", []byte{0x7F, 0xFF}},
+		{"9.001", []byte{0x0C}},
+		{"19.001", []byte{0x7B, 0x06, 0x0F}},
+	}
+
+	for _, test := range tests {
+		if value, err := Decode(mustParse(t, test.dpt), test.payload); err == nil {
+			t.Errorf("Decode(%s, % X) = %v, want an error", test.dpt, test.payload, value)
+		}
+	}
+}
+
+func TestDecodeYearWindow(t *testing.T) {
+	// 11.x payloads encode years 1990 to 1999 as 90 to 99 and years 2000 to 2089 as 0 to 89.
+	for payload, year := range map[byte]uint16{0: 2000, 89: 2089, 90: 1990, 99: 1999} {
+		value, err := Decode(ets.NewMainDatapointType(11), []byte{1, 1, payload})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if date := value.(Date); date.Year != year {
+			t.Errorf("Year %d decoded as %d, want %d", payload, date.Year, year)
+		}
+	}
+}
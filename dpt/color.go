@@ -0,0 +1,26 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package dpt
+
+// RGB is a value of datapoint type 232.600.
+type RGB struct {
+	R uint8
+	G uint8
+	B uint8
+}
+
+var rgbCodec = fixedCodec{
+	size: 3,
+	decode: func(data []byte) (interface{}, error) {
+		return RGB{R: data[0], G: data[1], B: data[2]}, nil
+	},
+	encode: func(value interface{}) ([]byte, error) {
+		c, ok := value.(RGB)
+		if !ok {
+			return nil, unexpectedValue(value, "RGB")
+		}
+
+		return []byte{c.R, c.G, c.B}, nil
+	},
+}
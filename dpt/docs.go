@@ -0,0 +1,50 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+/*
+Package dpt encodes and decodes the payloads of KNX telegrams according to their datapoint type.
+
+Use the datapoint type of a group address or communication object to find the matching codec.
+
+	temperature, err := ets.ParseDatapointType("DPST-9-1")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	value, err := dpt.Decode(temperature, []byte{0x0C, 0x29})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(value) // 21.3
+
+Values of datapoint types with less than 8 bits (e.g. 1.x, 2.x and 3.x) are encoded into a single
+byte which holds the value in its least significant bits.
+
+The Go types of decoded values depend on the datapoint type. Encoding accepts the same types,
+numeric datapoint types accept any numeric Go type.
+
+	1.x        bool
+	2.x        Control
+	3.x        StepControl
+	4.x, 16.x  string
+	5.001      float64 (0 to 100 percent)
+	5.003      float64 (0 to 360 degrees)
+	5.x        uint8
+	6.x        int8
+	7.x        uint16
+	8.x        int16
+	9.x        float64
+	10.001     TimeOfDay
+	11.001     Date
+	12.x       uint32
+	13.x       int32
+	14.x       float64
+	17.x       uint8
+	18.001     SceneControl
+	19.001     DateTime
+	20.x       uint8
+	29.x       int64
+	232.600    RGB
+*/
+package dpt
@@ -0,0 +1,311 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package dpt
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// toFloat converts any numeric value to float64.
+func toFloat(value interface{}) (float64, error) {
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case float32:
+		return float64(v), nil
+	case int:
+		return float64(v), nil
+	case int8:
+		return float64(v), nil
+	case int16:
+		return float64(v), nil
+	case int32:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case uint:
+		return float64(v), nil
+	case uint8:
+		return float64(v), nil
+	case uint16:
+		return float64(v), nil
+	case uint32:
+		return float64(v), nil
+	case uint64:
+		return float64(v), nil
+	default:
+		return 0, unexpectedValue(value, "numeric value")
+	}
+}
+
+// toInt converts any integer value to int64 and makes sure that it lies within the given range.
+func toInt(value interface{}, min, max int64) (int64, error) {
+	var n int64
+
+	switch v := value.(type) {
+	case int:
+		n = int64(v)
+	case int8:
+		n = int64(v)
+	case int16:
+		n = int64(v)
+	case int32:
+		n = int64(v)
+	case int64:
+		n = v
+	case uint:
+		if uint64(v) > math.MaxInt64 {
+			return 0, fmt.Errorf("Value %d is out of range", v)
+		}
+		n = int64(v)
+	case uint8:
+		n = int64(v)
+	case uint16:
+		n = int64(v)
+	case uint32:
+		n = int64(v)
+	case uint64:
+		if v > math.MaxInt64 {
+			return 0, fmt.Errorf("Value %d is out of range", v)
+		}
+		n = int64(v)
+	default:
+		return 0, unexpectedValue(value, "integer value")
+	}
+
+	if n < min || n > max {
+		return 0, fmt.Errorf("Value %d is out of range", n)
+	}
+
+	return n, nil
+}
+
+// toUint converts any integer value to uint64 and makes sure that it does not exceed max.
+func toUint(value interface{}, max uint64) (uint64, error) {
+	if v, ok := value.(uint64); ok {
+		if v > max {
+			return 0, fmt.Errorf("Value %d is out of range", v)
+		}
+
+		return v, nil
+	}
+
+	limit := int64(math.MaxInt64)
+	if max < math.MaxInt64 {
+		limit = int64(max)
+	}
+
+	n, err := toInt(value, 0, limit)
+	return uint64(n), err
+}
+
+var uint8Codec = fixedCodec{
+	size: 1,
+	decode: func(data []byte) (interface{}, error) {
+		return data[0], nil
+	},
+	encode: func(value interface{}) ([]byte, error) {
+		n, err := toUint(value, math.MaxUint8)
+		if err != nil {
+			return nil, err
+		}
+
+		return []byte{byte(n)}, nil
+	},
+}
+
+// scaledCodec maps the range 0 to 255 onto the range 0 to max.
+func scaledCodec(max float64) Codec {
+	return fixedCodec{
+		size: 1,
+		decode: func(data []byte) (interface{}, error) {
+			return float64(data[0]) * max / 255, nil
+		},
+		encode: func(value interface{}) ([]byte, error) {
+			f, err := toFloat(value)
+			if err != nil {
+				return nil, err
+			}
+
+			if f < 0 || f > max {
+				return nil, fmt.Errorf("Value %v is out of range", f)
+			}
+
+			return []byte{byte(math.Floor(f*255/max + 0.5))}, nil
+		},
+	}
+}
+
+var int8Codec = fixedCodec{
+	size: 1,
+	decode: func(data []byte) (interface{}, error) {
+		return int8(data[0]), nil
+	},
+	encode: func(value interface{}) ([]byte, error) {
+		n, err := toInt(value, math.MinInt8, math.MaxInt8)
+		if err != nil {
+			return nil, err
+		}
+
+		return []byte{byte(n)}, nil
+	},
+}
+
+var uint16Codec = fixedCodec{
+	size: 2,
+	decode: func(data []byte) (interface{}, error) {
+		return binary.BigEndian.Uint16(data), nil
+	},
+	encode: func(value interface{}) ([]byte, error) {
+		n, err := toUint(value, math.MaxUint16)
+		if err != nil {
+			return nil, err
+		}
+
+		data := make([]byte, 2)
+		binary.BigEndian.PutUint16(data, uint16(n))
+		return data, nil
+	},
+}
+
+var int16Codec = fixedCodec{
+	size: 2,
+	decode: func(data []byte) (interface{}, error) {
+		return int16(binary.BigEndian.Uint16(data)), nil
+	},
+	encode: func(value interface{}) ([]byte, error) {
+		n, err := toInt(value, math.MinInt16, math.MaxInt16)
+		if err != nil {
+			return nil, err
+		}
+
+		data := make([]byte, 2)
+		binary.BigEndian.PutUint16(data, uint16(n))
+		return data, nil
+	},
+}
+
+var uint32Codec = fixedCodec{
+	size: 4,
+	decode: func(data []byte) (interface{}, error) {
+		return binary.BigEndian.Uint32(data), nil
+	},
+	encode: func(value interface{}) ([]byte, error) {
+		n, err := toUint(value, math.MaxUint32)
+		if err != nil {
+			return nil, err
+		}
+
+		data := make([]byte, 4)
+		binary.BigEndian.PutUint32(data, uint32(n))
+		return data, nil
+	},
+}
+
+var int32Codec = fixedCodec{
+	size: 4,
+	decode: func(data []byte) (interface{}, error) {
+		return int32(binary.BigEndian.Uint32(data)), nil
+	},
+	encode: func(value interface{}) ([]byte, error) {
+		n, err := toInt(value, math.MinInt32, math.MaxInt32)
+		if err != nil {
+			return nil, err
+		}
+
+		data := make([]byte, 4)
+		binary.BigEndian.PutUint32(data, uint32(n))
+		return data, nil
+	},
+}
+
+var int64Codec = fixedCodec{
+	size: 8,
+	decode: func(data []byte) (interface{}, error) {
+		return int64(binary.BigEndian.Uint64(data)), nil
+	},
+	encode: func(value interface{}) ([]byte, error) {
+		n, err := toInt(value, math.MinInt64, math.MaxInt64)
+		if err != nil {
+			return nil, err
+		}
+
+		data := make([]byte, 8)
+		binary.BigEndian.PutUint64(data, uint64(n))
+		return data, nil
+	},
+}
+
+// float16Codec handles the 2-byte floats of 9.x datapoint types. They consist of a sign bit, a
+// 4-bit exponent and an 11-bit mantissa. The value is 0.01 * mantissa * 2^exponent where the
+// mantissa is in two's complement.
+var float16Codec = fixedCodec{
+	size: 2,
+	decode: func(data []byte) (interface{}, error) {
+		raw := binary.BigEndian.Uint16(data)
+		if raw == 0x7FFF {
+			return nil, fmt.Errorf("Payload contains an invalid value")
+		}
+
+		exponent := uint(raw>>11) & 0xF
+
+		mantissa := int(raw & 0x7FF)
+		if raw&0x8000 != 0 {
+			mantissa -= 2048
+		}
+
+		return 0.01 * float64(mantissa<<exponent), nil
+	},
+	encode: func(value interface{}) ([]byte, error) {
+		f, err := toFloat(value)
+		if err != nil {
+			return nil, err
+		}
+
+		if f < -671088.64 || f > 670760.96 {
+			return nil, fmt.Errorf("Value %v is out of range", f)
+		}
+
+		var exponent uint
+		mantissa := int(math.Floor(f*100 + 0.5))
+
+		for mantissa < -2048 || mantissa > 2047 {
+			exponent++
+			mantissa = int(math.Floor(f*100/float64(int(1)<<exponent) + 0.5))
+		}
+
+		raw := uint16(exponent)<<11 | uint16(mantissa)&0x7FF
+		if mantissa < 0 {
+			raw |= 0x8000
+		}
+
+		// 0x7FFF is reserved for invalid values.
+		if raw == 0x7FFF {
+			return nil, fmt.Errorf("Value %v is out of range", f)
+		}
+
+		data := make([]byte, 2)
+		binary.BigEndian.PutUint16(data, raw)
+		return data, nil
+	},
+}
+
+var float32Codec = fixedCodec{
+	size: 4,
+	decode: func(data []byte) (interface{}, error) {
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(data))), nil
+	},
+	encode: func(value interface{}) ([]byte, error) {
+		f, err := toFloat(value)
+		if err != nil {
+			return nil, err
+		}
+
+		data := make([]byte, 4)
+		binary.BigEndian.PutUint32(data, math.Float32bits(float32(f)))
+		return data, nil
+	},
+}
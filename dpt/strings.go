@@ -0,0 +1,67 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package dpt
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// encodeChars encodes s into size bytes which are padded with zeros. Characters beyond max can not
+// be encoded.
+func encodeChars(value interface{}, size int, max rune) ([]byte, error) {
+	s, ok := value.(string)
+	if !ok {
+		return nil, unexpectedValue(value, "string")
+	}
+
+	data := make([]byte, 0, size)
+	for _, r := range s {
+		if r > max {
+			return nil, fmt.Errorf("Character '%c' can not be encoded", r)
+		}
+
+		data = append(data, byte(r))
+	}
+
+	if len(data) > size {
+		return nil, fmt.Errorf("String '%s' exceeds %d characters", s, size)
+	}
+
+	return append(data, make([]byte, size-len(data))...), nil
+}
+
+// decodeChars decodes characters until the first zero byte. Each byte is a code point of
+// ISO 8859-1, which includes ASCII.
+func decodeChars(data []byte) string {
+	if end := bytes.IndexByte(data, 0); end >= 0 {
+		data = data[:end]
+	}
+
+	runes := make([]rune, len(data))
+	for n, b := range data {
+		runes[n] = rune(b)
+	}
+
+	return string(runes)
+}
+
+func charsCodec(size int, max rune) Codec {
+	return fixedCodec{
+		size: size,
+		decode: func(data []byte) (interface{}, error) {
+			return decodeChars(data), nil
+		},
+		encode: func(value interface{}) ([]byte, error) {
+			return encodeChars(value, size, max)
+		},
+	}
+}
+
+var (
+	charCodec         = charsCodec(1, 0x7F)
+	latin1CharCodec   = charsCodec(1, 0xFF)
+	stringCodec       = charsCodec(14, 0x7F)
+	latin1StringCodec = charsCodec(14, 0xFF)
+)
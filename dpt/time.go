@@ -0,0 +1,159 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package dpt
+
+import "fmt"
+
+// TimeOfDay is a value of datapoint type 10.001.
+type TimeOfDay struct {
+	// Weekday ranges from 1 (Monday) to 7 (Sunday). 0 means that there is no day.
+	Weekday uint8
+	Hour    uint8
+	Minute  uint8
+	Second  uint8
+}
+
+// Date is a value of datapoint type 11.001.
+type Date struct {
+	// Year ranges from 1990 to 2089.
+	Year  uint16
+	Month uint8
+	Day   uint8
+}
+
+// DateTime is a value of datapoint type 19.001.
+type DateTime struct {
+	// Year ranges from 1900 to 2155.
+	Year  uint16
+	Month uint8
+	Day   uint8
+
+	// Weekday ranges from 1 (Monday) to 7 (Sunday). 0 means that there is no day.
+	Weekday uint8
+	Hour    uint8
+	Minute  uint8
+	Second  uint8
+
+	Fault        bool
+	WorkingDay   bool
+	NoWorkingDay bool
+	NoYear       bool
+	NoDate       bool
+	NoWeekday    bool
+	NoTime       bool
+	SummerTime   bool
+
+	// ExternalSync indicates that the clock is synchronised to an external time signal.
+	ExternalSync bool
+}
+
+var timeOfDayCodec = fixedCodec{
+	size: 3,
+	decode: func(data []byte) (interface{}, error) {
+		return TimeOfDay{
+			Weekday: data[0] >> 5,
+			Hour:    data[0] & 0x1F,
+			Minute:  data[1] & 0x3F,
+			Second:  data[2] & 0x3F,
+		}, nil
+	},
+	encode: func(value interface{}) ([]byte, error) {
+		t, ok := value.(TimeOfDay)
+		if !ok {
+			return nil, unexpectedValue(value, "TimeOfDay")
+		}
+
+		if t.Weekday > 7 || t.Hour > 23 || t.Minute > 59 || t.Second > 59 {
+			return nil, fmt.Errorf("Time of day %+v is out of range", t)
+		}
+
+		return []byte{t.Weekday<<5 | t.Hour, t.Minute, t.Second}, nil
+	},
+}
+
+var dateCodec = fixedCodec{
+	size: 3,
+	decode: func(data []byte) (interface{}, error) {
+		year := uint16(data[2] & 0x7F)
+		if year < 90 {
+			year += 2000
+		} else {
+			year += 1900
+		}
+
+		return Date{Year: year, Month: data[1] & 0x0F, Day: data[0] & 0x1F}, nil
+	},
+	encode: func(value interface{}) ([]byte, error) {
+		d, ok := value.(Date)
+		if !ok {
+			return nil, unexpectedValue(value, "Date")
+		}
+
+		if d.Year < 1990 || d.Year > 2089 || d.Month < 1 || d.Month > 12 || d.Day < 1 || d.Day > 31 {
+			return nil, fmt.Errorf("Date %+v is out of range", d)
+		}
+
+		return []byte{d.Day, d.Month, byte(d.Year % 100)}, nil
+	},
+}
+
+var dateTimeCodec = fixedCodec{
+	size: 8,
+	decode: func(data []byte) (interface{}, error) {
+		return DateTime{
+			Year:         1900 + uint16(data[0]),
+			Month:        data[1] & 0x0F,
+			Day:          data[2] & 0x1F,
+			Weekday:      data[3] >> 5,
+			Hour:         data[3] & 0x1F,
+			Minute:       data[4] & 0x3F,
+			Second:       data[5] & 0x3F,
+			Fault:        data[6]&0x80 != 0,
+			WorkingDay:   data[6]&0x40 != 0,
+			NoWorkingDay: data[6]&0x20 != 0,
+			NoYear:       data[6]&0x10 != 0,
+			NoDate:       data[6]&0x08 != 0,
+			NoWeekday:    data[6]&0x04 != 0,
+			NoTime:       data[6]&0x02 != 0,
+			SummerTime:   data[6]&0x01 != 0,
+			ExternalSync: data[7]&0x80 != 0,
+		}, nil
+	},
+	encode: func(value interface{}) ([]byte, error) {
+		dt, ok := value.(DateTime)
+		if !ok {
+			return nil, unexpectedValue(value, "DateTime")
+		}
+
+		if dt.Year < 1900 || dt.Year > 2155 || dt.Month > 12 || dt.Day > 31 || dt.Weekday > 7 ||
+			dt.Hour > 24 || dt.Minute > 59 || dt.Second > 59 {
+			return nil, fmt.Errorf("Date and time %+v is out of range", dt)
+		}
+
+		var flags, quality byte
+		for bit, set := range []bool{
+			dt.SummerTime, dt.NoTime, dt.NoWeekday, dt.NoDate,
+			dt.NoYear, dt.NoWorkingDay, dt.WorkingDay, dt.Fault,
+		} {
+			if set {
+				flags |= 1 << uint(bit)
+			}
+		}
+
+		if dt.ExternalSync {
+			quality = 0x80
+		}
+
+		return []byte{
+			byte(dt.Year - 1900),
+			dt.Month,
+			dt.Day,
+			dt.Weekday<<5 | dt.Hour,
+			dt.Minute,
+			dt.Second,
+			flags,
+			quality,
+		}, nil
+	},
+}
@@ -0,0 +1,198 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package ets
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// AreaAddr is the address of an area, i.e. the area part of an individual address.
+type AreaAddr uint8
+
+// LineAddr is the address of a line within its area, i.e. the line part of an individual address.
+type LineAddr uint8
+
+// DeviceNumber is the address of a device within its line, i.e. the device part of an individual
+// address.
+type DeviceNumber uint8
+
+// IndividualAddr is the individual address of a device, e.g. 1.1.5.
+type IndividualAddr uint16
+
+// NewIndividualAddr3 generates an individual address from its area, line and device parts.
+func NewIndividualAddr3(area, line, device uint8) IndividualAddr {
+	return IndividualAddr(area&0xF)<<12 | IndividualAddr(line&0xF)<<8 | IndividualAddr(device)
+}
+
+// DeviceAddr composes the individual address of a device which is located on the given line within
+// the given area.
+func DeviceAddr(area *Area, line *Line, device *DeviceInstance) IndividualAddr {
+	return NewIndividualAddr3(uint8(area.Address), uint8(line.Address), uint8(device.Address))
+}
+
+// ParseIndividualAddr parses an individual address in the format "1.1.5".
+func ParseIndividualAddr(s string) (IndividualAddr, error) {
+	parts, err := parseAddrParts(s, ".", []uint{4, 4, 8})
+	if err != nil {
+		return 0, fmt.Errorf("Invalid individual address '%s': %v", s, err)
+	}
+
+	return NewIndividualAddr3(uint8(parts[0]), uint8(parts[1]), uint8(parts[2])), nil
+}
+
+// Area returns the area part of the address.
+func (addr IndividualAddr) Area() uint8 {
+	return uint8(addr>>12) & 0xF
+}
+
+// Line returns the line part of the address.
+func (addr IndividualAddr) Line() uint8 {
+	return uint8(addr>>8) & 0xF
+}
+
+// Device returns the device part of the address.
+func (addr IndividualAddr) Device() uint8 {
+	return uint8(addr)
+}
+
+// String generates a string representation in the format "1.1.5".
+func (addr IndividualAddr) String() string {
+	return fmt.Sprintf("%d.%d.%d", addr.Area(), addr.Line(), addr.Device())
+}
+
+// GroupAddr is a group address.
+type GroupAddr uint16
+
+// NewGroupAddr2 generates a group address from its main and sub group.
+func NewGroupAddr2(main uint8, sub uint16) GroupAddr {
+	return GroupAddr(main&0x1F)<<11 | GroupAddr(sub&0x7FF)
+}
+
+// NewGroupAddr3 generates a group address from its main, middle and sub group.
+func NewGroupAddr3(main, middle, sub uint8) GroupAddr {
+	return GroupAddr(main&0x1F)<<11 | GroupAddr(middle&0x7)<<8 | GroupAddr(sub)
+}
+
+// ParseGroupAddr parses a group address in three-level ("1/2/3"), two-level ("1/515") or free
+// ("2563") format.
+func ParseGroupAddr(s string) (GroupAddr, error) {
+	if parts, err := parseAddrParts(s, "/", []uint{5, 3, 8}); err == nil {
+		return NewGroupAddr3(uint8(parts[0]), uint8(parts[1]), uint8(parts[2])), nil
+	}
+
+	if parts, err := parseAddrParts(s, "/", []uint{5, 11}); err == nil {
+		return NewGroupAddr2(uint8(parts[0]), uint16(parts[1])), nil
+	}
+
+	if parts, err := parseAddrParts(s, "/", []uint{16}); err == nil {
+		return GroupAddr(parts[0]), nil
+	}
+
+	return 0, fmt.Errorf("Invalid group address '%s'", s)
+}
+
+// Main returns the main group of the address.
+func (addr GroupAddr) Main() uint8 {
+	return uint8(addr>>11) & 0x1F
+}
+
+// Middle returns the middle group of the address. Only meaningful for three-level addresses.
+func (addr GroupAddr) Middle() uint8 {
+	return uint8(addr>>8) & 0x7
+}
+
+// Sub returns the sub group of the address when using three-level addresses.
+func (addr GroupAddr) Sub() uint8 {
+	return uint8(addr)
+}
+
+// Sub2 returns the sub group of the address when using two-level addresses.
+func (addr GroupAddr) Sub2() uint16 {
+	return uint16(addr) & 0x7FF
+}
+
+// Format generates a string representation using the given style.
+func (addr GroupAddr) Format(style GroupAddrStyle) string {
+	switch style {
+	case GroupAddrStyleFree:
+		return strconv.FormatUint(uint64(addr), 10)
+
+	case GroupAddrStyleTwoLevel:
+		return fmt.Sprintf("%d/%d", addr.Main(), addr.Sub2())
+
+	default:
+		return fmt.Sprintf("%d/%d/%d", addr.Main(), addr.Middle(), addr.Sub())
+	}
+}
+
+// String generates a string representation in the three-level format "1/2/3". Use Format or
+// ProjectInfo.FormatGroupAddr to respect the style configured for a project.
+func (addr GroupAddr) String() string {
+	return addr.Format(GroupAddrStyleThreeLevel)
+}
+
+// GroupAddrStyle is the style in which group addresses are presented.
+type GroupAddrStyle int
+
+const (
+	// GroupAddrStyleThreeLevel presents group addresses as "main/middle/sub".
+	GroupAddrStyleThreeLevel GroupAddrStyle = iota
+
+	// GroupAddrStyleTwoLevel presents group addresses as "main/sub".
+	GroupAddrStyleTwoLevel
+
+	// GroupAddrStyleFree presents group addresses as a plain number.
+	GroupAddrStyleFree
+)
+
+// parseGroupAddrStyle parses the value of the 'GroupAddressStyle' attribute.
+func parseGroupAddrStyle(s string) GroupAddrStyle {
+	switch s {
+	case "TwoLevel":
+		return GroupAddrStyleTwoLevel
+
+	case "Free":
+		return GroupAddrStyleFree
+
+	default:
+		return GroupAddrStyleThreeLevel
+	}
+}
+
+// String returns the name of the style as used by ETS.
+func (style GroupAddrStyle) String() string {
+	switch style {
+	case GroupAddrStyleTwoLevel:
+		return "TwoLevel"
+
+	case GroupAddrStyleFree:
+		return "Free"
+
+	default:
+		return "ThreeLevel"
+	}
+}
+
+// parseAddrParts splits the address at the separator and parses each part. Each part must fit
+// into the corresponding number of bits.
+func parseAddrParts(s, sep string, bits []uint) ([]uint64, error) {
+	fields := strings.Split(s, sep)
+	if len(fields) != len(bits) {
+		return nil, fmt.Errorf("Expected %d parts in '%s'", len(bits), s)
+	}
+
+	parts := make([]uint64, len(fields))
+	for n, field := range fields {
+		part, err := strconv.ParseUint(strings.TrimSpace(field), 10, int(bits[n]))
+		if err != nil {
+			return nil, err
+		}
+
+		parts[n] = part
+	}
+
+	return parts, nil
+}
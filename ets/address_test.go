@@ -0,0 +1,119 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package ets
+
+import "testing"
+
+func TestParseIndividualAddr(t *testing.T) {
+	tests := []struct {
+		input string
+		want  IndividualAddr
+		ok    bool
+	}{
+		{"1.1.5", NewIndividualAddr3(1, 1, 5), true},
+		{"0.0.0", 0, true},
+		{"15.15.255", 0xFFFF, true},
+		{" 1 . 2 . 3 ", NewIndividualAddr3(1, 2, 3), true},
+
+		// Out of range
+		{"16.1.1", 0, false},
+		{"1.16.1", 0, false},
+		{"1.1.256", 0, false},
+
+		// Malformed
+		{"1.1", 0, false},
+		{"1.1.1.1", 0, false},
+		{"1/1/1", 0, false},
+		{"1.x.1", 0, false},
+		{"-1.1.1", 0, false},
+		{"", 0, false},
+	}
+
+	for _, test := range tests {
+		got, err := ParseIndividualAddr(test.input)
+		if (err == nil) != test.ok {
+			t.Errorf("ParseIndividualAddr(%q) returned error %v", test.input, err)
+			continue
+		}
+
+		if got != test.want {
+			t.Errorf("ParseIndividualAddr(%q) = %v, want %v", test.input, got, test.want)
+		}
+	}
+}
+
+func TestParseGroupAddr(t *testing.T) {
+	tests := []struct {
+		input string
+		want  GroupAddr
+		ok    bool
+	}{
+		// Three-level
+		{"1/2/3", NewGroupAddr3(1, 2, 3), true},
+		{"0/0/1", 1, true},
+		{"31/7/255", 0xFFFF, true},
+
+		// Two-level
+		{"1/515", NewGroupAddr3(1, 2, 3), true},
+		{"1/2047", NewGroupAddr2(1, 2047), true},
+		{"31/2047", 0xFFFF, true},
+
+		// Free
+		{"2563", NewGroupAddr3(1, 2, 3), true},
+		{"0", 0, true},
+		{"65535", 0xFFFF, true},
+
+		// Out of range
+		{"32/0/0", 0, false},
+		{"1/8/0", 0, false},
+		{"1/0/256", 0, false},
+		{"32/1", 0, false},
+		{"1/2048", 0, false},
+		{"65536", 0, false},
+
+		// Malformed
+		{"1/2/3/4", 0, false},
+		{"1.2.3", 0, false},
+		{"x", 0, false},
+		{"1//3", 0, false},
+		{"", 0, false},
+	}
+
+	for _, test := range tests {
+		got, err := ParseGroupAddr(test.input)
+		if (err == nil) != test.ok {
+			t.Errorf("ParseGroupAddr(%q) returned error %v", test.input, err)
+			continue
+		}
+
+		if got != test.want {
+			t.Errorf("ParseGroupAddr(%q) = %v, want %v", test.input, got, test.want)
+		}
+	}
+}
+
+func TestGroupAddrFormat(t *testing.T) {
+	addr := NewGroupAddr3(1, 2, 3)
+
+	tests := []struct {
+		style GroupAddrStyle
+		want  string
+	}{
+		{GroupAddrStyleThreeLevel, "1/2/3"},
+		{GroupAddrStyleTwoLevel, "1/515"},
+		{GroupAddrStyleFree, "2563"},
+	}
+
+	for _, test := range tests {
+		got := addr.Format(test.style)
+		if got != test.want {
+			t.Errorf("Format(%v) = %q, want %q", test.style, got, test.want)
+		}
+
+		// Formatted addresses parse back to the same address.
+		if parsed, err := ParseGroupAddr(got); err != nil || parsed != addr {
+			t.Errorf("ParseGroupAddr(%q) = %v, %v, want %v", got, parsed, err, addr)
+		}
+	}
+}
@@ -0,0 +1,368 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package ets
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DatapointType identifies a datapoint type by its main and sub number, e.g. 9.001.
+type DatapointType struct {
+	Main uint
+
+	// Sub is only valid if HasSub is true. Otherwise only the main type is known, e.g. "DPT-9".
+	Sub    uint
+	HasSub bool
+}
+
+// NewDatapointType creates a datapoint type with main and sub number.
+func NewDatapointType(main, sub uint) DatapointType {
+	return DatapointType{Main: main, Sub: sub, HasSub: true}
+}
+
+// NewMainDatapointType creates a datapoint type of which only the main number is known.
+func NewMainDatapointType(main uint) DatapointType {
+	return DatapointType{Main: main}
+}
+
+func parseDatapointNumber(s string) (uint, bool) {
+	n, err := strconv.ParseUint(s, 10, 16)
+	return uint(n), err == nil
+}
+
+// ParseDatapointType parses a single datapoint type. It accepts the ETS notations "DPT-9" and
+// "DPST-9-1" as well as the common notations "9" and "9.001".
+func ParseDatapointType(s string) (DatapointType, error) {
+	var parts []string
+
+	switch {
+	case strings.HasPrefix(s, "DPST-"):
+		parts = strings.Split(strings.TrimPrefix(s, "DPST-"), "-")
+		if len(parts) != 2 {
+			parts = nil
+		}
+
+	case strings.HasPrefix(s, "DPT-"):
+		parts = strings.Split(strings.TrimPrefix(s, "DPT-"), "-")
+		if len(parts) != 1 {
+			parts = nil
+		}
+
+	default:
+		parts = strings.Split(s, ".")
+		if len(parts) > 2 {
+			parts = nil
+		}
+	}
+
+	if len(parts) == 0 {
+		return DatapointType{}, fmt.Errorf("Invalid datapoint type '%s'", s)
+	}
+
+	main, ok := parseDatapointNumber(parts[0])
+	if !ok {
+		return DatapointType{}, fmt.Errorf("Invalid datapoint type '%s'", s)
+	}
+
+	if len(parts) == 1 {
+		return NewMainDatapointType(main), nil
+	}
+
+	sub, ok := parseDatapointNumber(parts[1])
+	if !ok {
+		return DatapointType{}, fmt.Errorf("Invalid datapoint type '%s'", s)
+	}
+
+	return NewDatapointType(main, sub), nil
+}
+
+// ParseDatapointTypes parses a space-separated list of datapoint types such as the
+// DatapointType attributes found in ETS projects, e.g. "DPST-1-1 DPT-5". An empty string yields no
+// datapoint types.
+func ParseDatapointTypes(s string) ([]DatapointType, error) {
+	var dpts []DatapointType
+
+	for _, field := range strings.Fields(s) {
+		dpt, err := ParseDatapointType(field)
+		if err != nil {
+			return nil, err
+		}
+
+		dpts = append(dpts, dpt)
+	}
+
+	return dpts, nil
+}
+
+// MainType strips the sub number.
+func (dpt DatapointType) MainType() DatapointType {
+	return NewMainDatapointType(dpt.Main)
+}
+
+// Compare orders datapoint types by their main and then their sub number. Main types are ordered
+// before their sub types. The result is negative, zero or positive if dpt is less than, equal to or
+// greater than other.
+func (dpt DatapointType) Compare(other DatapointType) int {
+	switch {
+	case dpt.Main != other.Main:
+		if dpt.Main < other.Main {
+			return -1
+		}
+
+		return 1
+
+	case dpt.HasSub != other.HasSub:
+		if !dpt.HasSub {
+			return -1
+		}
+
+		return 1
+
+	case dpt.Sub != other.Sub:
+		if dpt.Sub < other.Sub {
+			return -1
+		}
+
+		return 1
+	}
+
+	return 0
+}
+
+// Matches determines whether two datapoint types are the same. A main type matches all of its sub
+// types.
+func (dpt DatapointType) Matches(other DatapointType) bool {
+	if dpt.Main != other.Main {
+		return false
+	}
+
+	return !dpt.HasSub || !other.HasSub || dpt.Sub == other.Sub
+}
+
+// Compatible determines whether values of both datapoint types have the same encoding, which is
+// the case if they share the main number.
+func (dpt DatapointType) Compatible(other DatapointType) bool {
+	return dpt.Main == other.Main
+}
+
+// String generates a string representation in the format "9.001" or "9" for main types.
+func (dpt DatapointType) String() string {
+	if !dpt.HasSub {
+		return strconv.FormatUint(uint64(dpt.Main), 10)
+	}
+
+	return fmt.Sprintf("%d.%03d", dpt.Main, dpt.Sub)
+}
+
+// ETSString generates the notation used by ETS, e.g. "DPST-9-1" or "DPT-9".
+func (dpt DatapointType) ETSString() string {
+	if !dpt.HasSub {
+		return fmt.Sprintf("DPT-%d", dpt.Main)
+	}
+
+	return fmt.Sprintf("DPST-%d-%d", dpt.Main, dpt.Sub)
+}
+
+// Name returns the standard name of the datapoint type, e.g. "temperature (°C)". Sub types that are
+// not known fall back to the name of their main type. An empty string is returned if the main type
+// is not known either.
+func (dpt DatapointType) Name() string {
+	if dpt.HasSub {
+		if info, ok := datapointSubTypes[dpt]; ok {
+			return info.name
+		}
+	}
+
+	if info, ok := datapointMainTypes[dpt.Main]; ok {
+		return info.name
+	}
+
+	return ""
+}
+
+// Unit returns the unit of values of the datapoint type, e.g. "°C". An empty string is returned if
+// the values have no unit or the datapoint type is not known.
+func (dpt DatapointType) Unit() string {
+	if !dpt.HasSub {
+		return ""
+	}
+
+	return datapointSubTypes[dpt].unit
+}
+
+// Size returns the size of values of the datapoint type in bits. It returns 0 if the main type is
+// not known.
+func (dpt DatapointType) Size() uint {
+	return datapointMainTypes[dpt.Main].size
+}
+
+type datapointMainInfo struct {
+	name string
+	size uint
+}
+
+// datapointMainTypes lists the main types defined by the KNX standard.
+var datapointMainTypes = map[uint]datapointMainInfo{
+	1:   {"1-bit", 1},
+	2:   {"1-bit controlled", 2},
+	3:   {"3-bit controlled", 4},
+	4:   {"character", 8},
+	5:   {"8-bit unsigned value", 8},
+	6:   {"8-bit signed value", 8},
+	7:   {"2-byte unsigned value", 16},
+	8:   {"2-byte signed value", 16},
+	9:   {"2-byte float value", 16},
+	10:  {"time", 24},
+	11:  {"date", 24},
+	12:  {"4-byte unsigned value", 32},
+	13:  {"4-byte signed value", 32},
+	14:  {"4-byte float value", 32},
+	15:  {"entrance access", 32},
+	16:  {"character string", 112},
+	17:  {"scene number", 8},
+	18:  {"scene control", 8},
+	19:  {"date time", 64},
+	20:  {"1-byte", 8},
+	21:  {"8-bit set", 8},
+	22:  {"16-bit set", 16},
+	23:  {"2-bit set", 2},
+	24:  {"variable string", 0},
+	25:  {"2-nibble set", 8},
+	26:  {"8-bit set", 8},
+	27:  {"32-bit set", 32},
+	28:  {"UTF-8 string", 0},
+	29:  {"electrical energy", 64},
+	30:  {"24 times channel activation", 24},
+	217: {"datapoint type version", 16},
+	219: {"alarm info", 48},
+	221: {"serial number", 48},
+	225: {"scaling speed", 24},
+	231: {"locale", 32},
+	232: {"3-byte colour RGB", 24},
+	234: {"language code", 16},
+	235: {"active energy", 48},
+	237: {"DALI control gear diagnostic", 16},
+	238: {"DALI diagnostics", 8},
+	239: {"scaling step time", 16},
+	242: {"colour xyY", 48},
+	249: {"brightness colour temperature transition", 48},
+	250: {"brightness colour temperature control", 24},
+	251: {"colour RGBW", 48},
+}
+
+type datapointSubInfo struct {
+	name string
+	unit string
+}
+
+// datapointSubTypes lists commonly used sub types defined by the KNX standard.
+var datapointSubTypes = map[DatapointType]datapointSubInfo{
+	NewDatapointType(1, 1):     {"switch", ""},
+	NewDatapointType(1, 2):     {"boolean", ""},
+	NewDatapointType(1, 3):     {"enable", ""},
+	NewDatapointType(1, 4):     {"ramp", ""},
+	NewDatapointType(1, 5):     {"alarm", ""},
+	NewDatapointType(1, 6):     {"binary value", ""},
+	NewDatapointType(1, 7):     {"step", ""},
+	NewDatapointType(1, 8):     {"up/down", ""},
+	NewDatapointType(1, 9):     {"open/close", ""},
+	NewDatapointType(1, 10):    {"start/stop", ""},
+	NewDatapointType(1, 11):    {"state", ""},
+	NewDatapointType(1, 12):    {"invert", ""},
+	NewDatapointType(1, 13):    {"dim send style", ""},
+	NewDatapointType(1, 14):    {"input source", ""},
+	NewDatapointType(1, 15):    {"reset", ""},
+	NewDatapointType(1, 16):    {"acknowledge", ""},
+	NewDatapointType(1, 17):    {"trigger", ""},
+	NewDatapointType(1, 18):    {"occupancy", ""},
+	NewDatapointType(1, 19):    {"window/door", ""},
+	NewDatapointType(1, 21):    {"logical function", ""},
+	NewDatapointType(1, 22):    {"scene A/B", ""},
+	NewDatapointType(1, 23):    {"shutter/blinds mode", ""},
+	NewDatapointType(1, 24):    {"day/night", ""},
+	NewDatapointType(1, 100):   {"cooling/heating", ""},
+	NewDatapointType(2, 1):     {"switch control", ""},
+	NewDatapointType(2, 2):     {"boolean control", ""},
+	NewDatapointType(3, 7):     {"dimming control", ""},
+	NewDatapointType(3, 8):     {"blind control", ""},
+	NewDatapointType(4, 1):     {"character (ASCII)", ""},
+	NewDatapointType(4, 2):     {"character (ISO 8859-1)", ""},
+	NewDatapointType(5, 1):     {"percentage (0..100%)", "%"},
+	NewDatapointType(5, 3):     {"angle (degrees)", "°"},
+	NewDatapointType(5, 4):     {"percentage (0..255%)", "%"},
+	NewDatapointType(5, 5):     {"ratio (0..255)", ""},
+	NewDatapointType(5, 6):     {"tariff (0..255)", ""},
+	NewDatapointType(5, 10):    {"counter pulses (0..255)", "counter pulses"},
+	NewDatapointType(6, 1):     {"percentage (-128..127%)", "%"},
+	NewDatapointType(6, 10):    {"counter pulses (-128..127)", "counter pulses"},
+	NewDatapointType(7, 1):     {"pulses", "pulses"},
+	NewDatapointType(7, 2):     {"time (ms)", "ms"},
+	NewDatapointType(7, 3):     {"time (10 ms)", "ms"},
+	NewDatapointType(7, 4):     {"time (100 ms)", "ms"},
+	NewDatapointType(7, 5):     {"time (s)", "s"},
+	NewDatapointType(7, 6):     {"time (min)", "min"},
+	NewDatapointType(7, 7):     {"time (h)", "h"},
+	NewDatapointType(7, 11):    {"length (mm)", "mm"},
+	NewDatapointType(7, 12):    {"current (mA)", "mA"},
+	NewDatapointType(7, 13):    {"brightness (lux)", "lx"},
+	NewDatapointType(7, 600):   {"absolute colour temperature (K)", "K"},
+	NewDatapointType(8, 1):     {"pulses difference", "pulses"},
+	NewDatapointType(8, 2):     {"time lag (ms)", "ms"},
+	NewDatapointType(8, 5):     {"time lag (s)", "s"},
+	NewDatapointType(8, 10):    {"percentage difference (%)", "%"},
+	NewDatapointType(8, 11):    {"rotation angle (°)", "°"},
+	NewDatapointType(9, 1):     {"temperature (°C)", "°C"},
+	NewDatapointType(9, 2):     {"temperature difference (K)", "K"},
+	NewDatapointType(9, 3):     {"kelvin/hour (K/h)", "K/h"},
+	NewDatapointType(9, 4):     {"lux (Lux)", "lx"},
+	NewDatapointType(9, 5):     {"speed (m/s)", "m/s"},
+	NewDatapointType(9, 6):     {"pressure (Pa)", "Pa"},
+	NewDatapointType(9, 7):     {"humidity (%)", "%"},
+	NewDatapointType(9, 8):     {"parts/million (ppm)", "ppm"},
+	NewDatapointType(9, 10):    {"time (s)", "s"},
+	NewDatapointType(9, 11):    {"time (ms)", "ms"},
+	NewDatapointType(9, 20):    {"voltage (mV)", "mV"},
+	NewDatapointType(9, 21):    {"current (mA)", "mA"},
+	NewDatapointType(9, 22):    {"power density (W/m²)", "W/m²"},
+	NewDatapointType(9, 23):    {"kelvin/percent (K/%)", "K/%"},
+	NewDatapointType(9, 24):    {"power (kW)", "kW"},
+	NewDatapointType(9, 25):    {"volume flow (l/h)", "l/h"},
+	NewDatapointType(9, 26):    {"rain amount (l/m²)", "l/m²"},
+	NewDatapointType(9, 27):    {"temperature (°F)", "°F"},
+	NewDatapointType(9, 28):    {"wind speed (km/h)", "km/h"},
+	NewDatapointType(10, 1):    {"time of day", ""},
+	NewDatapointType(11, 1):    {"date", ""},
+	NewDatapointType(12, 1):    {"counter pulses (unsigned)", "counter pulses"},
+	NewDatapointType(13, 1):    {"counter pulses (signed)", "counter pulses"},
+	NewDatapointType(13, 2):    {"flow rate (m³/h)", "m³/h"},
+	NewDatapointType(13, 10):   {"active energy (Wh)", "Wh"},
+	NewDatapointType(13, 11):   {"apparent energy (VAh)", "VAh"},
+	NewDatapointType(13, 12):   {"reactive energy (VARh)", "VARh"},
+	NewDatapointType(13, 13):   {"active energy (kWh)", "kWh"},
+	NewDatapointType(13, 14):   {"apparent energy (kVAh)", "kVAh"},
+	NewDatapointType(13, 15):   {"reactive energy (kVARh)", "kVARh"},
+	NewDatapointType(13, 100):  {"time lag (s)", "s"},
+	NewDatapointType(14, 0):    {"acceleration", "m/s²"},
+	NewDatapointType(14, 7):    {"angle (degree)", "°"},
+	NewDatapointType(14, 19):   {"electric current", "A"},
+	NewDatapointType(14, 27):   {"electric potential", "V"},
+	NewDatapointType(14, 33):   {"frequency", "Hz"},
+	NewDatapointType(14, 56):   {"power", "W"},
+	NewDatapointType(14, 57):   {"power factor", ""},
+	NewDatapointType(14, 65):   {"speed", "m/s"},
+	NewDatapointType(14, 68):   {"temperature (°C)", "°C"},
+	NewDatapointType(14, 76):   {"volume", "m³"},
+	NewDatapointType(14, 77):   {"volume flux", "m³/s"},
+	NewDatapointType(16, 0):    {"character string (ASCII)", ""},
+	NewDatapointType(16, 1):    {"character string (ISO 8859-1)", ""},
+	NewDatapointType(17, 1):    {"scene number", ""},
+	NewDatapointType(18, 1):    {"scene control", ""},
+	NewDatapointType(19, 1):    {"date time", ""},
+	NewDatapointType(20, 102):  {"HVAC mode", ""},
+	NewDatapointType(20, 105):  {"HVAC control mode", ""},
+	NewDatapointType(232, 600): {"RGB value 3x(0..255)", ""},
+	NewDatapointType(251, 600): {"RGBW value 4x(0..100%)", "%"},
+}
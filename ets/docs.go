@@ -17,8 +17,14 @@ You can open exported projects and product databases.
 	// Make sure to close the archive eventually.
 	defer archive.Close()
 
+Archives that do not live on the file system can be opened using NewExportArchive, which reads
+from an io.ReaderAt, or OpenExportArchiveFS, which opens a file in an fs.FS.
+
+	archive, err := ets.NewExportArchive(bytes.NewReader(contents), int64(len(contents)))
+
 OpenExportArchive will scan for project and manufacturer files inside the given export archive.
 Project and manufacturer files will be stored in ProjectFiles and ManufacturerFiles respectively.
+The hardware and catalog files of manufacturers are stored in HardwareFiles and CatalogFiles.
 
 	for _, manuFile := range archive.ManufacturerFiles {
 		fmt.Println(manuFile.ContentID)
@@ -28,6 +34,15 @@ Project and manufacturer files will be stored in ProjectFiles and ManufacturerFi
 		fmt.Println(projFile.ProjectID)
 	}
 
+Password-protected projects
+
+Newer versions of ETS store projects in an archive (P-XXXX.zip) nested within the export archive.
+The project files inside such an archive are listed alongside the other project files. Projects
+that have been exported with a password are encrypted. Use OpenExportArchiveWithPassword to open
+these. The files are decrypted transparently when you open or decode them.
+
+	archive, err := ets.OpenExportArchiveWithPassword("my-project.knxproj", "my-password")
+
 Decoding files
 
 Not all files within the export might be relevant to you. Therefore, no files are decoded
@@ -59,5 +74,110 @@ files. Each installation file can contain multiple project installations. Yes, t
 		}
 	}
 
+Addresses
+
+Group addresses are of type GroupAddr. Use ProjectInfo.FormatGroupAddr to present them in the style
+that has been configured for the project. The individual address of a device is composed of the
+addresses of its area, line and the device itself.
+
+	for _, area := range inst.Topology {
+		for _, line := range area.Lines {
+			for _, device := range line.Devices {
+				fmt.Println(ets.DeviceAddr(&area, &line, &device), device.Name)
+			}
+		}
+	}
+
+Each line has a medium type such as MediumTP or MediumIP. Couplers are the devices with the device
+address 0 within their line. IP devices carry their IP configuration.
+
+	if line.MediumType == ets.MediumIP && device.IsCoupler() && device.IPConfig != nil {
+		fmt.Println(device.Name, "routes via", device.IPConfig.IPAddress)
+	}
+
+Locations
+
+Buildings, floors, rooms and other locations form a tree. DeviceLocations tells where each device
+has been placed.
+
+	locations := inst.DeviceLocations()
+	if path, ok := locations[device.ID]; ok {
+		fmt.Println(device.Name, "is located in", path[len(path)-1].Name)
+	}
+
+Locations also contain functions, e.g. a light in a room. A function refers to its group addresses
+and the role each of them plays. GroupAddressIndex finds the functions of a group address.
+
+	for _, link := range idx.Functions(addr.ID) {
+		fmt.Println(addr.Name, "is", link.Ref.Role, "of", link.Function.Name)
+	}
+
+Trades group devices by their trade. DeviceTrades works like DeviceLocations.
+
+Communication objects
+
+The properties of a device's communication objects are spread across the project and the
+manufacturer data. ComObjectResolver merges them into an effective view.
+
+	resolver := ets.NewComObjectResolver(manufacturers)
+	for _, comObj := range resolver.ResolveDevice(&device) {
+		fmt.Println(comObj.Text, comObj.DatapointType, comObj.WriteFlag)
+	}
+
+Devices refer to their product and application programs in the manufacturer data.
+ResolveDeviceHardware follows these references.
+
+	hw := ets.ResolveDeviceHardware(&device, manufacturers)
+	if hw.Product != nil {
+		fmt.Println(hw.Product.Text, hw.Product.OrderNumber, device.DownloadPending())
+	}
+
+Parameters
+
+ParameterResolver merges the parameter values of a device with the parameter definitions of its
+application programs. Parameters which the device does not override have their default values.
+
+	resolver := ets.NewParameterResolver(manufacturers)
+	for _, param := range resolver.ResolveDevice(&device) {
+		fmt.Println(param.Text, param.DisplayValue(), param.IsDefault())
+	}
+
+The Dynamic section of an application program determines which parameters and communication
+objects are visible in ETS, depending on the parameter values of the device.
+
+	for _, prog := range resolver.Programs(&device) {
+		active := ets.EvaluateDynamic(&device, prog)
+		fmt.Println(len(active.ParameterRefs), len(active.ComObjectRefs))
+	}
+
+Assign items are taken into account. Modules and repeated items are not evaluated; ActiveRefs marks
+the result as incomplete if the device uses them.
+
+Datapoint types
+
+DatapointType attributes contain space-separated lists in ETS notation, e.g. "DPST-9-1 DPT-1".
+ParseDatapointTypes turns them into values of type DatapointType.
+
+	dpts, err := ets.ParseDatapointTypes(comObj.DatapointType)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, dpt := range dpts {
+		fmt.Println(dpt, dpt.Name(), dpt.Unit(), dpt.Size())
+	}
+
+The master data of an export contains the authoritative definitions of datapoint types, medium
+types and manufacturers.
+
+	if archive.MasterDataFile != nil {
+		md, err := archive.MasterDataFile.Decode()
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Println(md.ManufacturerName("M-0083"))
+	}
+
 */
 package ets
@@ -0,0 +1,330 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package ets
+
+import (
+	"strconv"
+	"strings"
+)
+
+// DynamicKind is the kind of an item within the Dynamic section of an application program.
+type DynamicKind int
+
+const (
+	// DynamicChannel is a channel of the device. Its items are ParameterBlocks and choose items.
+	DynamicChannel DynamicKind = iota
+
+	// DynamicChannelIndependentBlock contains items which do not belong to a channel.
+	DynamicChannelIndependentBlock
+
+	// DynamicParameterBlock is a page of parameters.
+	DynamicParameterBlock
+
+	// DynamicParameterRefRef makes a parameter reference visible.
+	DynamicParameterRefRef
+
+	// DynamicComObjectRefRef makes a communication object reference available.
+	DynamicComObjectRefRef
+
+	// DynamicChoose selects its when items based on the value of a parameter.
+	DynamicChoose
+
+	// DynamicWhen contains items that are active if its test matches the value of the parameter
+	// of the surrounding choose item.
+	DynamicWhen
+
+	// DynamicAssign sets the value of a parameter reference, either to a constant value or to the
+	// value of another parameter reference.
+	DynamicAssign
+
+	// DynamicRename changes the text of a channel or parameter block. It does not affect which
+	// references are active.
+	DynamicRename
+
+	// DynamicModule instantiates a module definition of the application program.
+	DynamicModule
+
+	// DynamicRepeat repeats its items a number of times.
+	DynamicRepeat
+)
+
+// DynamicItem is an item within the Dynamic section of an application program.
+type DynamicItem struct {
+	Kind DynamicKind
+
+	// ID, Name and Text identify channels and parameter blocks.
+	ID   string
+	Name string
+	Text string
+
+	// ParameterRefID is the parameter reference of ParameterRefRef and choose items and the target
+	// of Assign items.
+	ParameterRefID ParameterRefID
+
+	// SourceParameterRefID and Value are the source of Assign items. Value is only used if there
+	// is no source parameter reference.
+	SourceParameterRefID ParameterRefID
+	Value                string
+
+	// ComObjectRefID is the communication object reference of ComObjectRefRef items.
+	ComObjectRefID ComObjectRefID
+
+	// Test and Default are the condition of a when item. Test is a space-separated list of values,
+	// optionally prefixed by one of the operators <, <=, >, >= or !=. Default when items are
+	// active if no other when item within the same choose item is.
+	Test    string
+	Default bool
+
+	Items []DynamicItem
+}
+
+// ActiveRefs contains the parameter and communication object references that are active
+// according to the Dynamic section of an application program.
+type ActiveRefs struct {
+	ParameterRefs []ParameterRefID
+	ComObjectRefs []ComObjectRefID
+
+	// Incomplete is set if an active part of the Dynamic section instantiates modules or repeats
+	// items. These are not evaluated, so the references they would make active are missing.
+	Incomplete bool
+
+	parameterRefs map[ParameterRefID]bool
+	comObjectRefs map[ComObjectRefID]bool
+}
+
+// HasParameterRef determines whether the parameter reference is active.
+func (ar *ActiveRefs) HasParameterRef(id ParameterRefID) bool {
+	return ar.parameterRefs[id]
+}
+
+// HasComObjectRef determines whether the communication object reference is active.
+func (ar *ActiveRefs) HasComObjectRef(id ComObjectRefID) bool {
+	return ar.comObjectRefs[id]
+}
+
+// parseTest splits the test of a when item into its operator and its values.
+func parseTest(test string) (string, []string) {
+	test = strings.TrimSpace(test)
+
+	for _, op := range []string{"<=", ">=", "!=", "<", ">"} {
+		if strings.HasPrefix(test, op) {
+			return op, strings.Fields(strings.TrimPrefix(test, op))
+		}
+	}
+
+	return "=", strings.Fields(test)
+}
+
+// compareValues compares two parameter values numerically if possible. Otherwise they are compared
+// as strings.
+func compareValues(a, b string) int {
+	x, errX := strconv.ParseFloat(a, 64)
+	y, errY := strconv.ParseFloat(b, 64)
+
+	switch {
+	case errX != nil || errY != nil:
+		return strings.Compare(a, b)
+
+	case x < y:
+		return -1
+
+	case x > y:
+		return 1
+
+	default:
+		return 0
+	}
+}
+
+// matchTest determines whether the value matches the test of a when item. The value has to match
+// one of the operands, except for the operator != which requires the value to differ from all of
+// them.
+func matchTest(test, value string) bool {
+	op, operands := parseTest(test)
+
+	if op == "!=" {
+		for _, operand := range operands {
+			if compareValues(value, operand) == 0 {
+				return false
+			}
+		}
+
+		return len(operands) > 0
+	}
+
+	for _, operand := range operands {
+		cmp := compareValues(value, operand)
+
+		var match bool
+		switch op {
+		case "<":
+			match = cmp < 0
+		case "<=":
+			match = cmp <= 0
+		case ">":
+			match = cmp > 0
+		case ">=":
+			match = cmp >= 0
+		default:
+			match = cmp == 0
+		}
+
+		if match {
+			return true
+		}
+	}
+
+	return false
+}
+
+// dynamicEvaluator walks the Dynamic section.
+type dynamicEvaluator struct {
+	values map[ParameterRefID]string
+	result *ActiveRefs
+
+	// changed is set if an Assign item has changed the value of a parameter reference.
+	changed bool
+}
+
+func (e *dynamicEvaluator) walk(items []DynamicItem) {
+	for n := range items {
+		item := &items[n]
+
+		switch item.Kind {
+		case DynamicParameterRefRef:
+			if !e.result.parameterRefs[item.ParameterRefID] {
+				e.result.parameterRefs[item.ParameterRefID] = true
+				e.result.ParameterRefs = append(e.result.ParameterRefs, item.ParameterRefID)
+			}
+
+		case DynamicComObjectRefRef:
+			if !e.result.comObjectRefs[item.ComObjectRefID] {
+				e.result.comObjectRefs[item.ComObjectRefID] = true
+				e.result.ComObjectRefs = append(e.result.ComObjectRefs, item.ComObjectRefID)
+			}
+
+		case DynamicChoose:
+			e.choose(item)
+
+		case DynamicWhen:
+			// When items outside of choose items are never active.
+
+		case DynamicAssign:
+			e.assign(item)
+
+		case DynamicRename:
+			// Renaming only changes texts.
+
+		case DynamicModule, DynamicRepeat:
+			e.result.Incomplete = true
+
+		default:
+			e.walk(item.Items)
+		}
+	}
+}
+
+func (e *dynamicEvaluator) assign(item *DynamicItem) {
+	value := item.Value
+	if item.SourceParameterRefID != "" {
+		value = e.values[item.SourceParameterRefID]
+	}
+
+	if e.values[item.ParameterRefID] != value {
+		e.values[item.ParameterRefID] = value
+		e.changed = true
+	}
+}
+
+func (e *dynamicEvaluator) choose(item *DynamicItem) {
+	value := e.values[item.ParameterRefID]
+	matched := false
+
+	for n := range item.Items {
+		when := &item.Items[n]
+		if when.Kind == DynamicWhen && !when.Default && matchTest(when.Test, value) {
+			matched = true
+			e.walk(when.Items)
+		}
+	}
+
+	if matched {
+		return
+	}
+
+	for n := range item.Items {
+		when := &item.Items[n]
+		if when.Kind == DynamicWhen && when.Default {
+			e.walk(when.Items)
+		}
+	}
+}
+
+// ParameterValues determines the value of each parameter reference of the program for the given
+// device. Values of the device take precedence over the defaults of the program.
+func ParameterValues(device *DeviceInstance, prog *ApplicationProgram) map[ParameterRefID]string {
+	params := map[ParameterID]*Parameter{}
+	for n := range prog.Parameters {
+		params[prog.Parameters[n].ID] = &prog.Parameters[n]
+	}
+
+	values := map[ParameterRefID]string{}
+
+	for _, ref := range prog.ParameterRefs {
+		var paramValue *string
+		if param, ok := params[ref.RefID]; ok {
+			paramValue = &param.Value
+		}
+
+		values[ref.ID] = mergeString(ref.Value, paramValue)
+	}
+
+	for _, inst := range device.Parameters {
+		id := inst.RefID
+
+		// Newer projects refer to parameters without the program prefix.
+		if _, ok := values[id]; !ok {
+			id = ParameterRefID(string(prog.ID) + "_" + string(id))
+		}
+
+		if _, ok := values[id]; ok {
+			values[id] = inst.Value
+		}
+	}
+
+	return values
+}
+
+// maxDynamicPasses limits how often the Dynamic section is evaluated while Assign items keep
+// changing parameter values.
+const maxDynamicPasses = 16
+
+// EvaluateDynamic determines the parameter and communication object references which are active
+// for the device according to the Dynamic section of the application program.
+func EvaluateDynamic(device *DeviceInstance, prog *ApplicationProgram) *ActiveRefs {
+	values := ParameterValues(device, prog)
+
+	var result *ActiveRefs
+
+	// Assign items may change the values that choose items have already tested. The section is
+	// evaluated again until the values settle.
+	for pass := 0; pass < maxDynamicPasses; pass++ {
+		e := &dynamicEvaluator{
+			values: values,
+			result: &ActiveRefs{
+				parameterRefs: map[ParameterRefID]bool{},
+				comObjectRefs: map[ComObjectRefID]bool{},
+			},
+		}
+
+		e.walk(prog.Dynamic)
+		result = e.result
+
+		if !e.changed {
+			break
+		}
+	}
+
+	return result
+}
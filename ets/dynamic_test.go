@@ -0,0 +1,184 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package ets
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMatchTest(t *testing.T) {
+	tests := []struct {
+		test  string
+		value string
+		want  bool
+	}{
+		{"1", "1", true},
+		{"1", "2", false},
+		{"1 2 3", "2", true},
+		{"1 2 3", "4", false},
+		{"1", "1.0", true},
+		{"Text", "Text", true},
+		{"Text", "text", false},
+		{"<5", "4", true},
+		{"<5", "5", false},
+		{"<=5", "5", true},
+		{">5", "6", true},
+		{">5", "5", false},
+		{">=5", "5", true},
+		{"!=1", "2", true},
+		{"!=1", "1", false},
+		{"!=1 2", "3", true},
+		{"!=1 2", "2", false},
+		{"!=1 2", "1", false},
+		{"", "1", false},
+	}
+
+	for _, test := range tests {
+		if got := matchTest(test.test, test.value); got != test.want {
+			t.Errorf("matchTest(%q, %q) = %v, want %v", test.test, test.value, got, test.want)
+		}
+	}
+}
+
+func TestParameterValues(t *testing.T) {
+	refValue := "3"
+
+	prog := &ApplicationProgram{
+		ID: "M-0083_A-0001-01-0000",
+		Parameters: []Parameter{
+			{ID: "M-0083_A-0001-01-0000_P-1", Value: "1"},
+			{ID: "M-0083_A-0001-01-0000_P-2", Value: "2"},
+		},
+		ParameterRefs: []ParameterRef{
+			{ID: "M-0083_A-0001-01-0000_P-1_R-1", RefID: "M-0083_A-0001-01-0000_P-1"},
+			{ID: "M-0083_A-0001-01-0000_P-2_R-2", RefID: "M-0083_A-0001-01-0000_P-2", Value: &refValue},
+			{ID: "M-0083_A-0001-01-0000_P-1_R-3", RefID: "M-0083_A-0001-01-0000_P-1"},
+			{ID: "M-0083_A-0001-01-0000_P-2_R-4", RefID: "M-0083_A-0001-01-0000_P-2"},
+		},
+	}
+
+	device := &DeviceInstance{
+		Parameters: []ParameterInstanceRef{
+			{RefID: "M-0083_A-0001-01-0000_P-1_R-3", Value: "10"},
+			{RefID: "P-2_R-4", Value: "20"},
+			{RefID: "P-9_R-9", Value: "90"},
+		},
+	}
+
+	want := map[ParameterRefID]string{
+		"M-0083_A-0001-01-0000_P-1_R-1": "1",
+		"M-0083_A-0001-01-0000_P-2_R-2": "3",
+		"M-0083_A-0001-01-0000_P-1_R-3": "10",
+		"M-0083_A-0001-01-0000_P-2_R-4": "20",
+	}
+
+	if got := ParameterValues(device, prog); !reflect.DeepEqual(got, want) {
+		t.Errorf("ParameterValues() = %v, want %v", got, want)
+	}
+}
+
+// dynamicTestProgram switches communication objects based on the parameter P-1. Its value may be
+// overridden by an Assign item depending on the parameter P-2.
+const dynamicTestProgram = `<KNX xmlns="http://knx.org/xml/project/20">
+  <ManufacturerData>
+    <Manufacturer RefId="M-0083">
+      <ApplicationPrograms>
+        <ApplicationProgram Id="A-1">
+          <Static>
+            <Parameters>
+              <Parameter Id="A-1_P-1" Value="0" />
+              <Parameter Id="A-1_P-2" Value="0" />
+            </Parameters>
+            <ParameterRefs>
+              <ParameterRef Id="A-1_P-1_R-1" RefId="A-1_P-1" />
+              <ParameterRef Id="A-1_P-2_R-2" RefId="A-1_P-2" />
+            </ParameterRefs>
+          </Static>
+          <Dynamic>
+            <ChannelIndependentBlock>
+              <ParameterBlock Id="A-1_PB-1" Name="General">
+                <ParameterRefRef RefId="A-1_P-1_R-1" />
+                <Rename Id="A-1_PB-1_RN-1" RefId="A-1_PB-1" Text="Renamed" />
+              </ParameterBlock>
+              <choose ParamRefId="A-1_P-1_R-1">
+                <when test="1 2">
+                  <ComObjectRefRef RefId="A-1_O-1_R-1" />
+                </when>
+                <when test="!=0 1 2">
+                  <ComObjectRefRef RefId="A-1_O-2_R-2" />
+                </when>
+                <when default="true">
+                  <ComObjectRefRef RefId="A-1_O-3_R-3" />
+                </when>
+              </choose>
+              <choose ParamRefId="A-1_P-2_R-2">
+                <when test="1">
+                  <Assign TargetParamRefRef="A-1_P-1_R-1" Value="2" />
+                </when>
+                <when test="2">
+                  <Module Id="A-1_MD-1_M-1" RefId="A-1_MD-1" />
+                </when>
+              </choose>
+            </ChannelIndependentBlock>
+          </Dynamic>
+        </ApplicationProgram>
+      </ApplicationPrograms>
+    </Manufacturer>
+  </ManufacturerData>
+</KNX>`
+
+func TestEvaluateDynamic(t *testing.T) {
+	md, err := DecodeManufacturerData(strings.NewReader(dynamicTestProgram))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prog := &md.Programs[0]
+
+	tests := []struct {
+		p1, p2     string
+		comObjects []ComObjectRefID
+		incomplete bool
+	}{
+		// Default when items are active only if no other when item matches.
+		{"0", "0", []ComObjectRefID{"A-1_O-3_R-3"}, false},
+		{"1", "0", []ComObjectRefID{"A-1_O-1_R-1"}, false},
+		{"3", "0", []ComObjectRefID{"A-1_O-2_R-2"}, false},
+
+		// The Assign item changes the value that the first choose item has already tested.
+		{"0", "1", []ComObjectRefID{"A-1_O-1_R-1"}, false},
+		{"3", "1", []ComObjectRefID{"A-1_O-1_R-1"}, false},
+
+		// Modules are not evaluated.
+		{"0", "2", []ComObjectRefID{"A-1_O-3_R-3"}, true},
+	}
+
+	for _, test := range tests {
+		device := &DeviceInstance{
+			Parameters: []ParameterInstanceRef{
+				{RefID: "P-1_R-1", Value: test.p1},
+				{RefID: "P-2_R-2", Value: test.p2},
+			},
+		}
+
+		active := EvaluateDynamic(device, prog)
+
+		if !reflect.DeepEqual(active.ComObjectRefs, test.comObjects) {
+			t.Errorf("P-1 = %s, P-2 = %s: got %v, want %v",
+				test.p1, test.p2, active.ComObjectRefs, test.comObjects)
+		}
+
+		if active.Incomplete != test.incomplete {
+			t.Errorf("P-1 = %s, P-2 = %s: Incomplete = %v, want %v",
+				test.p1, test.p2, active.Incomplete, test.incomplete)
+		}
+
+		if !active.HasParameterRef("A-1_P-1_R-1") || active.HasParameterRef("A-1_P-2_R-2") {
+			t.Errorf("P-1 = %s, P-2 = %s: unexpected parameters %v",
+				test.p1, test.p2, active.ParameterRefs)
+		}
+	}
+}
@@ -5,15 +5,49 @@ package ets
 
 import (
 	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"io/ioutil"
 	"path"
 	"regexp"
+	"strconv"
+	"strings"
 )
 
+// archiveSource is the nested archive that a file has been found in. It is nil for files that are
+// located in the export archive itself.
+type archiveSource struct {
+	reader   io.ReaderAt
+	password []byte
+}
+
+// open the given file. Encrypted files are decrypted transparently.
+func (src *archiveSource) open(file *zip.File) (io.ReadCloser, error) {
+	if src == nil || file.Method != winZipAESMethod {
+		return file.Open()
+	}
+
+	if src.password == nil {
+		return nil, ErrPasswordRequired
+	}
+
+	return openEncryptedFile(src.reader, file, src.password)
+}
+
 // InstallationFile is a file that contains zero or more project installations.
 type InstallationFile struct {
 	*zip.File
 
 	InstallationID string
+
+	source *archiveSource
+}
+
+// Open returns a ReadCloser that provides access to the file's contents. Contents of encrypted
+// files are decrypted.
+func (i *InstallationFile) Open() (io.ReadCloser, error) {
+	return i.source.open(i.File)
 }
 
 // Decode the file in order to retrieve the project inside it.
@@ -35,6 +69,14 @@ type ProjectFile struct {
 
 	ProjectID         string
 	InstallationFiles []InstallationFile
+
+	source *archiveSource
+}
+
+// Open returns a ReadCloser that provides access to the file's contents. Contents of encrypted
+// files are decrypted.
+func (pf *ProjectFile) Open() (io.ReadCloser, error) {
+	return pf.source.open(pf.File)
 }
 
 // Decode the file in order to retrieve the project info inside it.
@@ -52,14 +94,20 @@ func (pf *ProjectFile) Decode() (pi *ProjectInfo, err error) {
 
 var projectFileBaseRe = regexp.MustCompile("^(\\d).xml$")
 
-func newProjectFile(archive *zip.ReadCloser, metaFile *zip.File) (projFile ProjectFile) {
+func newProjectFile(
+	files []*zip.File,
+	source *archiveSource,
+	projectID string,
+	metaFile *zip.File,
+) (projFile ProjectFile) {
 	projectDir := path.Dir(metaFile.Name)
 
 	projFile.File = metaFile
-	projFile.ProjectID = projectDir
+	projFile.ProjectID = projectID
+	projFile.source = source
 
 	// Search for the project installation file.
-	for _, file := range archive.File {
+	for _, file := range files {
 		if path.Dir(file.Name) != projectDir {
 			continue
 		}
@@ -68,6 +116,7 @@ func newProjectFile(archive *zip.ReadCloser, metaFile *zip.File) (projFile Proje
 			projFile.InstallationFiles = append(projFile.InstallationFiles, InstallationFile{
 				File:           file,
 				InstallationID: matches[1],
+				source:         source,
 			})
 		}
 	}
@@ -80,7 +129,10 @@ type ManufacturerFile struct {
 	*zip.File
 
 	ManufacturerID string
-	ContentID      string
+
+	// ContentID is the ID of the application program in the file. It is "Hardware" or "Catalog"
+	// for the files in ExportArchive.HardwareFiles and ExportArchive.CatalogFiles.
+	ContentID string
 }
 
 // Decode the file in order to retrieve the manufacturer data inside it.
@@ -96,24 +148,57 @@ func (mf *ManufacturerFile) Decode() (md *ManufacturerData, err error) {
 	return
 }
 
+// MasterDataFile is the file that contains the KNX master data (knx_master.xml).
+type MasterDataFile struct {
+	*zip.File
+}
+
+// Decode the file in order to retrieve the master data inside it.
+func (mdf *MasterDataFile) Decode() (md *MasterData, err error) {
+	r, err := mdf.Open()
+	if err != nil {
+		return
+	}
+
+	md, err = DecodeMasterData(r)
+	r.Close()
+
+	return
+}
+
 // ExportArchive is a handle to an exported archive (.knxproj or .knxprod).
 type ExportArchive struct {
-	archive *zip.ReadCloser
+	archive *zip.Reader
+	closer  io.Closer
 
 	ProjectFiles      []ProjectFile
 	ManufacturerFiles []ManufacturerFile
+
+	// HardwareFiles and CatalogFiles contain the hardware and the catalog of a manufacturer. They
+	// can be decoded like manufacturer files but contain no application programs.
+	HardwareFiles []ManufacturerFile
+	CatalogFiles  []ManufacturerFile
+
+	// MasterDataFile is nil if the archive does not contain master data.
+	MasterDataFile *MasterDataFile
 }
 
 // OpenExportArchive opens the exported archive located at given path.
 func OpenExportArchive(path string) (*ExportArchive, error) {
+	return OpenExportArchiveWithPassword(path, "")
+}
+
+// OpenExportArchiveWithPassword opens the exported archive located at given path. The password is
+// used to decrypt password-protected projects within the archive. If the password is empty,
+// protected projects are still listed but opening their files fails with ErrPasswordRequired.
+func OpenExportArchiveWithPassword(path, password string) (*ExportArchive, error) {
 	archive, err := zip.OpenReader(path)
 	if err != nil {
 		return nil, err
 	}
 
-	ex := &ExportArchive{archive: archive}
-
-	if err = ex.findFiles(); err != nil {
+	ex, err := newExportArchive(&archive.Reader, archive, password)
+	if err != nil {
 		archive.Close()
 		return nil, err
 	}
@@ -121,30 +206,172 @@ func OpenExportArchive(path string) (*ExportArchive, error) {
 	return ex, nil
 }
 
+// NewExportArchive reads the exported archive from r, which is assumed to have the given size in
+// bytes. The caller remains responsible for r. Closing the resulting archive does not close r.
+func NewExportArchive(r io.ReaderAt, size int64) (*ExportArchive, error) {
+	return NewExportArchiveWithPassword(r, size, "")
+}
+
+// NewExportArchiveWithPassword is like NewExportArchive but uses the password to decrypt
+// password-protected projects. See OpenExportArchiveWithPassword.
+func NewExportArchiveWithPassword(r io.ReaderAt, size int64, password string) (*ExportArchive, error) {
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+
+	return newExportArchive(archive, nil, password)
+}
+
+func newExportArchive(archive *zip.Reader, closer io.Closer, password string) (*ExportArchive, error) {
+	ex := &ExportArchive{archive: archive, closer: closer}
+
+	if err := ex.findFiles(password); err != nil {
+		return nil, err
+	}
+
+	return ex, nil
+}
+
 var (
-	projectMetaFileRe  = regexp.MustCompile("^(p|P)-([0-9a-zA-Z]+)/(p|P)roject.xml$")
-	manufacturerFileRe = regexp.MustCompile("^(m|M)-([0-9a-zA-Z]+)/(m|M)-([^.]+).xml$")
+	projectMetaFileRe    = regexp.MustCompile("^(p|P)-([0-9a-zA-Z]+)/(p|P)roject.xml$")
+	projectArchiveFileRe = regexp.MustCompile("^(p|P)-([0-9a-zA-Z]+).zip$")
+	projectBaseRe        = regexp.MustCompile("^(p|P)roject.xml$")
+	manufacturerFileRe   = regexp.MustCompile("^(m|M)-([0-9a-zA-Z]+)/(m|M)-([^.]+).xml$")
+	hardwareFileRe       = regexp.MustCompile("^(m|M)-([0-9a-zA-Z]+)/(Hardware|Catalog).xml$")
+	masterDataFileRe     = regexp.MustCompile("^(k|K)nx_(m|M)aster.xml$")
 
 	// TODO: Figure out if '/' is a universal path seperator in ZIP files.
 )
 
-func (ex *ExportArchive) findFiles() error {
+// getSchemaVersion extracts the schema version from a namespace such as
+// "http://knx.org/xml/project/13". It returns 0 if the namespace does not carry a version.
+func getSchemaVersion(ns string) int {
+	version, err := strconv.Atoi(ns[strings.LastIndex(ns, "/")+1:])
+	if err != nil {
+		return 0
+	}
+
+	return version
+}
+
+// findSchemaVersion determines the schema version of the export by looking at the namespace of the
+// master data file.
+func (ex *ExportArchive) findSchemaVersion() (int, error) {
+	for _, file := range ex.archive.File {
+		if !masterDataFileRe.MatchString(file.Name) {
+			continue
+		}
+
+		r, err := file.Open()
+		if err != nil {
+			return 0, err
+		}
+		defer r.Close()
+
+		d := xml.NewDecoder(r)
+		for {
+			token, err := d.Token()
+			if err != nil {
+				return 0, err
+			}
+
+			if start, ok := token.(xml.StartElement); ok {
+				return getSchemaVersion(getNamespace(start)), nil
+			}
+		}
+	}
+
+	return 0, nil
+}
+
+// findNestedProject looks for the project inside a project archive (P-XXXX.zip) which is nested in
+// the export archive. The entries of the nested archive may be encrypted.
+func (ex *ExportArchive) findNestedProject(file *zip.File, password []byte) error {
+	r, err := file.Open()
+	if err != nil {
+		return err
+	}
+
+	contents, err := ioutil.ReadAll(r)
+	r.Close()
+	if err != nil {
+		return err
+	}
+
+	reader := bytes.NewReader(contents)
+	archive, err := zip.NewReader(reader, int64(len(contents)))
+	if err != nil {
+		return err
+	}
+
+	source := &archiveSource{reader: reader, password: password}
+	projectID := strings.TrimSuffix(file.Name, path.Ext(file.Name))
+
+	for _, innerFile := range archive.File {
+		if projectBaseRe.MatchString(path.Base(innerFile.Name)) {
+			ex.ProjectFiles = append(
+				ex.ProjectFiles,
+				newProjectFile(archive.File, source, projectID, innerFile),
+			)
+		}
+	}
+
+	return nil
+}
+
+func (ex *ExportArchive) findFiles(password string) error {
+	var projectPasswordBytes []byte
+	if password != "" {
+		schemaVersion, err := ex.findSchemaVersion()
+		if err != nil {
+			return err
+		}
+
+		projectPasswordBytes = projectPassword(password, schemaVersion)
+	}
+
 	for _, file := range ex.archive.File {
 		if projectMetaFileRe.MatchString(file.Name) {
-			ex.ProjectFiles = append(ex.ProjectFiles, newProjectFile(ex.archive, file))
+			ex.ProjectFiles = append(
+				ex.ProjectFiles,
+				newProjectFile(ex.archive.File, nil, path.Dir(file.Name), file),
+			)
+		} else if projectArchiveFileRe.MatchString(file.Name) {
+			if err := ex.findNestedProject(file, projectPasswordBytes); err != nil {
+				return err
+			}
 		} else if matches := manufacturerFileRe.FindStringSubmatch(file.Name); matches != nil {
 			ex.ManufacturerFiles = append(ex.ManufacturerFiles, ManufacturerFile{
 				File:           file,
 				ManufacturerID: "M-" + matches[2],
 				ContentID:      "M-" + matches[4],
 			})
+		} else if matches := hardwareFileRe.FindStringSubmatch(file.Name); matches != nil {
+			manuFile := ManufacturerFile{
+				File:           file,
+				ManufacturerID: "M-" + matches[2],
+				ContentID:      matches[3],
+			}
+
+			if manuFile.ContentID == "Hardware" {
+				ex.HardwareFiles = append(ex.HardwareFiles, manuFile)
+			} else {
+				ex.CatalogFiles = append(ex.CatalogFiles, manuFile)
+			}
+		} else if masterDataFileRe.MatchString(file.Name) {
+			ex.MasterDataFile = &MasterDataFile{File: file}
 		}
 	}
 
 	return nil
 }
 
-// Close the archive handle.
+// Close the archive handle. Readers that have been passed to NewExportArchive are not closed.
 func (ex *ExportArchive) Close() error {
-	return ex.archive.Close()
+	if ex.closer == nil {
+		return nil
+	}
+
+	return ex.closer.Close()
 }
@@ -0,0 +1,65 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+//go:build go1.16
+// +build go1.16
+
+package ets
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+)
+
+// OpenExportArchiveFS opens the exported archive with the given name in fsys.
+func OpenExportArchiveFS(fsys fs.FS, name string) (*ExportArchive, error) {
+	return OpenExportArchiveFSWithPassword(fsys, name, "")
+}
+
+// OpenExportArchiveFSWithPassword is like OpenExportArchiveFS but uses the password to decrypt
+// password-protected projects. See OpenExportArchiveWithPassword.
+func OpenExportArchiveFSWithPassword(fsys fs.FS, name, password string) (*ExportArchive, error) {
+	file, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	// Files that do not support random access need to be loaded into memory. They are not needed
+	// afterwards, so the archive has nothing to close.
+	r, ok := file.(io.ReaderAt)
+	size := info.Size()
+	closer := io.Closer(file)
+
+	if !ok {
+		contents, err := io.ReadAll(file)
+		file.Close()
+
+		if err != nil {
+			return nil, err
+		}
+
+		r = bytes.NewReader(contents)
+		size = int64(len(contents))
+		closer = nil
+	}
+
+	ex, err := NewExportArchiveWithPassword(r, size, password)
+	if err != nil {
+		if closer != nil {
+			closer.Close()
+		}
+
+		return nil, err
+	}
+
+	ex.closer = closer
+
+	return ex, nil
+}
@@ -0,0 +1,52 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package ets
+
+// DeviceHardware links a device to the manufacturer data that describes it. Fields are nil if the
+// corresponding manufacturer data is missing.
+type DeviceHardware struct {
+	Device           *DeviceInstance
+	Hardware         *Hardware
+	Product          *Product
+	Hardware2Program *Hardware2Program
+	Programs         []*ApplicationProgram
+}
+
+// ResolveDeviceHardware looks up the product, the hardware and the application programs of the
+// device in the given manufacturer data.
+func ResolveDeviceHardware(device *DeviceInstance, manufacturers []*ManufacturerData) DeviceHardware {
+	dh := DeviceHardware{Device: device}
+
+	for _, md := range manufacturers {
+		if dh.Product == nil && device.ProductRefID != "" {
+			dh.Hardware, dh.Product = md.Product(device.ProductRefID)
+		}
+
+		if dh.Hardware2Program == nil && device.Hardware2ProgramRefID != "" {
+			hw, h2p := md.Hardware2Program(device.Hardware2ProgramRefID)
+			if h2p != nil {
+				dh.Hardware2Program = h2p
+
+				if dh.Hardware == nil {
+					dh.Hardware = hw
+				}
+			}
+		}
+	}
+
+	if dh.Hardware2Program == nil {
+		return dh
+	}
+
+	for _, progID := range dh.Hardware2Program.ApplicationProgramRefs {
+		for _, md := range manufacturers {
+			if prog := md.Program(progID); prog != nil {
+				dh.Programs = append(dh.Programs, prog)
+				break
+			}
+		}
+	}
+
+	return dh
+}
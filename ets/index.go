@@ -0,0 +1,169 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package ets
+
+// ComObjectKey identifies a communication object of a particular device.
+type ComObjectKey struct {
+	Device DeviceInstanceID
+	RefID  ComObjectRefID
+}
+
+// Connection connects a communication object of a device with a group address.
+type Connection struct {
+	Device    *DeviceInstance
+	ComObject *ComObjectInstanceRef
+
+	// GroupAddress is nil if the project does not contain the group address.
+	GroupAddress   *GroupAddress
+	GroupAddressID GroupAddressID
+
+	// Receive is true if the communication object only listens on the group address. Otherwise,
+	// the communication object sends to the group address.
+	Receive bool
+}
+
+// FunctionLink attaches a group address to a function.
+type FunctionLink struct {
+	Function *Function
+	Ref      *GroupAddressRef
+
+	// Locations contains the function, from the outermost to the innermost location.
+	Locations []*Location
+}
+
+// GroupAddressIndex allows you to look up which communication objects are connected to a group
+// address and vice versa.
+type GroupAddressIndex struct {
+	devices   map[DeviceInstanceID]*DeviceInstance
+	addresses map[GroupAddressID]*GroupAddress
+	byAddress map[GroupAddressID][]Connection
+	byObject  map[ComObjectKey][]Connection
+	functions map[GroupAddressID][]FunctionLink
+}
+
+// NewGroupAddressIndex builds an index for the given project. The index refers to elements of the
+// project, therefore the project must not be modified while the index is in use.
+func NewGroupAddressIndex(proj *Project) *GroupAddressIndex {
+	idx := &GroupAddressIndex{
+		devices:   map[DeviceInstanceID]*DeviceInstance{},
+		addresses: map[GroupAddressID]*GroupAddress{},
+		byAddress: map[GroupAddressID][]Connection{},
+		byObject:  map[ComObjectKey][]Connection{},
+		functions: map[GroupAddressID][]FunctionLink{},
+	}
+
+	for i := range proj.Installations {
+		proj.Installations[i].WalkGroupAddresses(func(ranges []*GroupRange, addr *GroupAddress) {
+			idx.addresses[addr.ID] = addr
+		})
+	}
+
+	for i := range proj.Installations {
+		proj.Installations[i].WalkDevices(func(area *Area, line *Line, device *DeviceInstance) {
+			idx.addDevice(device)
+		})
+	}
+
+	for i := range proj.Installations {
+		proj.Installations[i].WalkFunctions(func(locations []*Location, function *Function) {
+			for n := range function.GroupAddressRefs {
+				ref := &function.GroupAddressRefs[n]
+				idx.functions[ref.RefID] = append(idx.functions[ref.RefID], FunctionLink{
+					Function:  function,
+					Ref:       ref,
+					Locations: locations,
+				})
+			}
+		})
+	}
+
+	return idx
+}
+
+func (idx *GroupAddressIndex) addDevice(device *DeviceInstance) {
+	idx.devices[device.ID] = device
+
+	for n := range device.ComObjects {
+		comObj := &device.ComObjects[n]
+		key := ComObjectKey{Device: device.ID, RefID: comObj.RefID}
+
+		for _, conn := range comObj.Connectors {
+			c := Connection{
+				Device:         device,
+				ComObject:      comObj,
+				GroupAddress:   idx.addresses[conn.RefID],
+				GroupAddressID: conn.RefID,
+				Receive:        conn.Receive,
+			}
+
+			idx.byAddress[conn.RefID] = append(idx.byAddress[conn.RefID], c)
+			idx.byObject[key] = append(idx.byObject[key], c)
+		}
+	}
+}
+
+// Device returns the device with the given ID or nil if there is no such device.
+func (idx *GroupAddressIndex) Device(id DeviceInstanceID) *DeviceInstance {
+	return idx.devices[id]
+}
+
+// GroupAddress returns the group address with the given ID or nil if there is no such group
+// address.
+func (idx *GroupAddressIndex) GroupAddress(id GroupAddressID) *GroupAddress {
+	return idx.addresses[id]
+}
+
+// Connections returns all communication objects that are connected to the group address.
+func (idx *GroupAddressIndex) Connections(id GroupAddressID) []Connection {
+	return idx.byAddress[id]
+}
+
+func filterConnections(conns []Connection, receive bool) []Connection {
+	var result []Connection
+
+	for _, conn := range conns {
+		if conn.Receive == receive {
+			result = append(result, conn)
+		}
+	}
+
+	return result
+}
+
+// Senders returns the communication objects that send to the group address.
+func (idx *GroupAddressIndex) Senders(id GroupAddressID) []Connection {
+	return filterConnections(idx.byAddress[id], false)
+}
+
+// Receivers returns the communication objects that only listen on the group address.
+func (idx *GroupAddressIndex) Receivers(id GroupAddressID) []Connection {
+	return filterConnections(idx.byAddress[id], true)
+}
+
+// ComObjectConnections returns the group addresses that the communication object of the device is
+// connected to.
+func (idx *GroupAddressIndex) ComObjectConnections(device DeviceInstanceID, ref ComObjectRefID) []Connection {
+	return idx.byObject[ComObjectKey{Device: device, RefID: ref}]
+}
+
+// DeviceConnections returns the group addresses that any communication object of the device is
+// connected to.
+func (idx *GroupAddressIndex) DeviceConnections(id DeviceInstanceID) []Connection {
+	device := idx.devices[id]
+	if device == nil {
+		return nil
+	}
+
+	var result []Connection
+	for _, comObj := range device.ComObjects {
+		result = append(result, idx.byObject[ComObjectKey{Device: id, RefID: comObj.RefID}]...)
+	}
+
+	return result
+}
+
+// Functions returns the functions that the group address is attached to.
+func (idx *GroupAddressIndex) Functions(id GroupAddressID) []FunctionLink {
+	return idx.functions[id]
+}
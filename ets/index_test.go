@@ -0,0 +1,168 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package ets
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// testIndexProject contains two devices whose communication objects send to and listen on the
+// group addresses GA-1 and GA-2. One communication object refers to the missing group address
+// GA-9.
+var testIndexProject = Project{
+	Installations: []Installation{{
+		Topology: []Area{{
+			Lines: []Line{{
+				Devices: []DeviceInstance{
+					{
+						ID: "DI-1",
+						ComObjects: []ComObjectInstanceRef{
+							{
+								RefID: "O-1_R-1",
+								Connectors: []Connector{
+									{RefID: "GA-1"},
+									{RefID: "GA-2", Receive: true},
+								},
+							},
+							{
+								RefID:      "O-2_R-2",
+								Connectors: []Connector{{RefID: "GA-1", Receive: true}},
+							},
+						},
+					},
+					{
+						ID: "DI-2",
+						ComObjects: []ComObjectInstanceRef{{
+							RefID: "O-1_R-1",
+							Connectors: []Connector{
+								{RefID: "GA-2"},
+								{RefID: "GA-1", Receive: true},
+								{RefID: "GA-9"},
+							},
+						}},
+					},
+				},
+			}},
+		}},
+		GroupAddresses: []GroupRange{{
+			ID: "GR-1",
+			Addresses: []GroupAddress{
+				{ID: "GA-1", Name: "Switch"},
+				{ID: "GA-2", Name: "Status"},
+			},
+		}},
+		Locations: []Location{{
+			ID: "BP-1",
+			SubLocations: []Location{{
+				ID: "BP-2",
+				Functions: []Function{{
+					ID:               "F-1",
+					GroupAddressRefs: []GroupAddressRef{{ID: "GAR-1", RefID: "GA-1", Role: "SwitchOnOff"}},
+				}},
+			}},
+		}},
+	}},
+}
+
+// describeConnections turns connections into strings of the form "device/object>address" for
+// senders and "device/object<address" for receivers.
+func describeConnections(conns []Connection) []string {
+	var result []string
+
+	for _, conn := range conns {
+		dir := ">"
+		if conn.Receive {
+			dir = "<"
+		}
+
+		result = append(result, fmt.Sprintf("%s/%s%s%s", conn.Device.ID, conn.ComObject.RefID, dir, conn.GroupAddressID))
+	}
+
+	return result
+}
+
+func TestGroupAddressIndex(t *testing.T) {
+	idx := NewGroupAddressIndex(&testIndexProject)
+
+	tests := []struct {
+		name string
+		got  []Connection
+		want []string
+	}{
+		{
+			"Connections(GA-1)",
+			idx.Connections("GA-1"),
+			[]string{"DI-1/O-1_R-1>GA-1", "DI-1/O-2_R-2<GA-1", "DI-2/O-1_R-1<GA-1"},
+		},
+		{"Senders(GA-1)", idx.Senders("GA-1"), []string{"DI-1/O-1_R-1>GA-1"}},
+		{"Receivers(GA-1)", idx.Receivers("GA-1"), []string{"DI-1/O-2_R-2<GA-1", "DI-2/O-1_R-1<GA-1"}},
+		{"Senders(GA-2)", idx.Senders("GA-2"), []string{"DI-2/O-1_R-1>GA-2"}},
+		{"Receivers(GA-2)", idx.Receivers("GA-2"), []string{"DI-1/O-1_R-1<GA-2"}},
+		{"Connections(GA-3)", idx.Connections("GA-3"), nil},
+		{
+			"ComObjectConnections(DI-1, O-1_R-1)",
+			idx.ComObjectConnections("DI-1", "O-1_R-1"),
+			[]string{"DI-1/O-1_R-1>GA-1", "DI-1/O-1_R-1<GA-2"},
+		},
+		{
+			// Communication objects are told apart by their device.
+			"ComObjectConnections(DI-2, O-1_R-1)",
+			idx.ComObjectConnections("DI-2", "O-1_R-1"),
+			[]string{"DI-2/O-1_R-1>GA-2", "DI-2/O-1_R-1<GA-1", "DI-2/O-1_R-1>GA-9"},
+		},
+		{
+			"DeviceConnections(DI-1)",
+			idx.DeviceConnections("DI-1"),
+			[]string{"DI-1/O-1_R-1>GA-1", "DI-1/O-1_R-1<GA-2", "DI-1/O-2_R-2<GA-1"},
+		},
+		{"DeviceConnections(DI-3)", idx.DeviceConnections("DI-3"), nil},
+	}
+
+	for _, test := range tests {
+		if got := describeConnections(test.got); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s = %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestGroupAddressIndexLookup(t *testing.T) {
+	idx := NewGroupAddressIndex(&testIndexProject)
+
+	for _, conn := range idx.DeviceConnections("DI-2") {
+		switch conn.GroupAddressID {
+		case "GA-9":
+			if conn.GroupAddress != nil {
+				t.Errorf("Missing group address resolved to %+v", conn.GroupAddress)
+			}
+
+		default:
+			if conn.GroupAddress == nil || conn.GroupAddress.ID != conn.GroupAddressID {
+				t.Errorf("Group address %s resolved to %+v", conn.GroupAddressID, conn.GroupAddress)
+			}
+		}
+	}
+
+	if device := idx.Device("DI-2"); device == nil || device.ID != "DI-2" {
+		t.Errorf("Device(DI-2) = %+v", device)
+	}
+
+	if addr := idx.GroupAddress("GA-2"); addr == nil || addr.Name != "Status" {
+		t.Errorf("GroupAddress(GA-2) = %+v", addr)
+	}
+
+	links := idx.Functions("GA-1")
+	if len(links) != 1 || links[0].Function.ID != "F-1" || links[0].Ref.Role != "SwitchOnOff" {
+		t.Fatalf("Functions(GA-1) = %+v", links)
+	}
+
+	if len(links[0].Locations) != 2 || links[0].Locations[1].ID != "BP-2" {
+		t.Errorf("Function is located in %+v", links[0].Locations)
+	}
+
+	if links := idx.Functions("GA-2"); len(links) != 0 {
+		t.Errorf("Functions(GA-2) = %+v", links)
+	}
+}
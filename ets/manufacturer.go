@@ -54,11 +54,78 @@ type ApplicationProgramID string
 
 // ApplicationProgram is an application program.
 type ApplicationProgram struct {
-	ID         ApplicationProgramID
-	Name       string
-	Version    uint
-	Objects    []ComObject
-	ObjectRefs []ComObjectRef
+	ID             ApplicationProgramID
+	Name           string
+	Version        uint
+	Objects        []ComObject
+	ObjectRefs     []ComObjectRef
+	ParameterTypes []ParameterType
+	Parameters     []Parameter
+	Unions         []ParameterUnion
+	ParameterRefs  []ParameterRef
+	Dynamic        []DynamicItem
+}
+
+// ProductID is the ID of a product.
+type ProductID string
+
+// Product is a product that can be ordered.
+type Product struct {
+	ID            ProductID
+	Text          string
+	OrderNumber   string
+	IsRailMounted bool
+}
+
+// Hardware2ProgramID is the ID of a Hardware2Program.
+type Hardware2ProgramID string
+
+// Hardware2Program maps hardware onto the application programs that can be loaded into it.
+type Hardware2Program struct {
+	ID                     Hardware2ProgramID
+	MediumTypes            []MediumTypeID
+	ApplicationProgramRefs []ApplicationProgramID
+}
+
+// HardwareID is the ID of hardware.
+type HardwareID string
+
+// Hardware is the hardware of one or more products.
+type Hardware struct {
+	ID                    HardwareID
+	Name                  string
+	SerialNumber          string
+	VersionNumber         uint
+	BusCurrent            float64
+	HasIndividualAddress  bool
+	HasApplicationProgram bool
+	Products              []Product
+	Hardware2Programs     []Hardware2Program
+}
+
+// CatalogItemID is the ID of a catalog item.
+type CatalogItemID string
+
+// CatalogItem is an entry in the product catalog.
+type CatalogItem struct {
+	ID                    CatalogItemID
+	Name                  string
+	Number                string
+	VisibleDescription    string
+	ProductRefID          ProductID
+	Hardware2ProgramRefID Hardware2ProgramID
+}
+
+// CatalogSectionID is the ID of a catalog section.
+type CatalogSectionID string
+
+// CatalogSection is a section of the product catalog.
+type CatalogSection struct {
+	ID       CatalogSectionID
+	Name     string
+	Number   string
+	Items    []CatalogItem
+	Sections []CatalogSection
 }
 
 // ManufacturerID is the ID of a manufacturer.
@@ -68,6 +135,69 @@ type ManufacturerID string
 type ManufacturerData struct {
 	Manufacturer ManufacturerID
 	Programs     []ApplicationProgram
+	Hardware     []Hardware
+	Catalog      []CatalogSection
+}
+
+// Program finds the application program with the given ID.
+func (md *ManufacturerData) Program(id ApplicationProgramID) *ApplicationProgram {
+	for n := range md.Programs {
+		if md.Programs[n].ID == id {
+			return &md.Programs[n]
+		}
+	}
+
+	return nil
+}
+
+// Product finds the product with the given ID and the hardware it belongs to.
+func (md *ManufacturerData) Product(id ProductID) (*Hardware, *Product) {
+	for n := range md.Hardware {
+		hw := &md.Hardware[n]
+
+		for m := range hw.Products {
+			if hw.Products[m].ID == id {
+				return hw, &hw.Products[m]
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// Hardware2Program finds the Hardware2Program with the given ID and the hardware it belongs to.
+func (md *ManufacturerData) Hardware2Program(id Hardware2ProgramID) (*Hardware, *Hardware2Program) {
+	for n := range md.Hardware {
+		hw := &md.Hardware[n]
+
+		for m := range hw.Hardware2Programs {
+			if hw.Hardware2Programs[m].ID == id {
+				return hw, &hw.Hardware2Programs[m]
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// WalkCatalog calls fn for each item in the catalog. The sections that contain the item are given
+// from the outermost to the innermost section.
+func (md *ManufacturerData) WalkCatalog(fn func(sections []*CatalogSection, item *CatalogItem)) {
+	for n := range md.Catalog {
+		walkCatalogSection([]*CatalogSection{&md.Catalog[n]}, fn)
+	}
+}
+
+func walkCatalogSection(sections []*CatalogSection, fn func(sections []*CatalogSection, item *CatalogItem)) {
+	section := sections[len(sections)-1]
+
+	for n := range section.Items {
+		fn(sections, &section.Items[n])
+	}
+
+	for n := range section.Sections {
+		walkCatalogSection(append(sections[:len(sections):len(sections)], &section.Sections[n]), fn)
+	}
 }
 
 // UnmarshalXML implements xml.Unmarshaler.
@@ -75,7 +205,8 @@ func (md *ManufacturerData) UnmarshalXML(d *xml.Decoder, start xml.StartElement)
 	// Decide which schema to use based on the value of the 'xmlns' attribute.
 	ns := getNamespace(start)
 	switch ns {
-	case schema11Namespace, schema12Namespace, schema13Namespace:
+	case schema11Namespace, schema12Namespace, schema13Namespace, schema14Namespace,
+		schema20Namespace, schema21Namespace:
 		return d.DecodeElement((*manufacturerData11)(md), &start)
 
 	default:
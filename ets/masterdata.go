@@ -0,0 +1,170 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package ets
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+// DatapointFormat is a field within the values of a datapoint subtype.
+type DatapointFormat struct {
+	// Kind is the kind of field, e.g. "Bit", "UnsignedInteger", "Float", "String" or
+	// "Enumeration".
+	Kind  string
+	ID    string
+	Name  string
+	Width uint
+	Unit  string
+}
+
+// DatapointSubtypeDefinition is the definition of a datapoint subtype.
+type DatapointSubtypeDefinition struct {
+	ID      string
+	Type    DatapointType
+	Name    string
+	Text    string
+	Default bool
+	Format  []DatapointFormat
+}
+
+// Unit returns the first unit found in the format of the subtype.
+func (sub *DatapointSubtypeDefinition) Unit() string {
+	for _, format := range sub.Format {
+		if format.Unit != "" {
+			return format.Unit
+		}
+	}
+
+	return ""
+}
+
+// DatapointTypeDefinition is the definition of a datapoint type.
+type DatapointTypeDefinition struct {
+	ID        string
+	Type      DatapointType
+	Name      string
+	Text      string
+	SizeInBit uint
+	Subtypes  []DatapointSubtypeDefinition
+}
+
+// MediumTypeID is the ID of a medium type.
+type MediumTypeID string
+
+// These are the IDs of the medium types defined by the master data.
+const (
+	MediumTP    MediumTypeID = "MT-0"
+	MediumPL110 MediumTypeID = "MT-1"
+	MediumRF    MediumTypeID = "MT-2"
+	MediumIP    MediumTypeID = "MT-5"
+)
+
+// MediumType is a transmission medium, e.g. twisted pair.
+type MediumType struct {
+	ID     MediumTypeID
+	Number uint
+	Name   string
+	Text   string
+}
+
+// Manufacturer is a registered manufacturer of KNX devices.
+type Manufacturer struct {
+	ID                ManufacturerID
+	Name              string
+	KnxManufacturerID uint
+	DefaultLanguage   string
+}
+
+// MasterData contains the definitions that are shared by all projects and manufacturer data.
+type MasterData struct {
+	Version        string
+	DatapointTypes []DatapointTypeDefinition
+	MediumTypes    []MediumType
+	Manufacturers  []Manufacturer
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+func (md *MasterData) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	// Decide which schema to use based on the value of the 'xmlns' attribute.
+	ns := getNamespace(start)
+	switch ns {
+	case schema11Namespace, schema12Namespace, schema13Namespace, schema14Namespace,
+		schema20Namespace, schema21Namespace:
+		return d.DecodeElement((*masterData11)(md), &start)
+
+	default:
+		return fmt.Errorf("Unexpected namespace '%s'", ns)
+	}
+}
+
+// DecodeMasterData parses the contents of a master data file.
+func DecodeMasterData(r io.Reader) (*MasterData, error) {
+	md := &MasterData{}
+	if err := xml.NewDecoder(r).Decode(md); err != nil {
+		return nil, err
+	}
+
+	return md, nil
+}
+
+// Manufacturer finds the manufacturer with the given ID.
+func (md *MasterData) Manufacturer(id ManufacturerID) *Manufacturer {
+	for n := range md.Manufacturers {
+		if md.Manufacturers[n].ID == id {
+			return &md.Manufacturers[n]
+		}
+	}
+
+	return nil
+}
+
+// ManufacturerName returns the name of the manufacturer with the given ID. The ID itself is
+// returned if the manufacturer is not known.
+func (md *MasterData) ManufacturerName(id ManufacturerID) string {
+	if manu := md.Manufacturer(id); manu != nil {
+		return manu.Name
+	}
+
+	return string(id)
+}
+
+// MediumType finds the medium type with the given ID.
+func (md *MasterData) MediumType(id MediumTypeID) *MediumType {
+	for n := range md.MediumTypes {
+		if md.MediumTypes[n].ID == id {
+			return &md.MediumTypes[n]
+		}
+	}
+
+	return nil
+}
+
+// DatapointType finds the definition of the main type of the given datapoint type.
+func (md *MasterData) DatapointType(dpt DatapointType) *DatapointTypeDefinition {
+	for n := range md.DatapointTypes {
+		if md.DatapointTypes[n].Type.Main == dpt.Main {
+			return &md.DatapointTypes[n]
+		}
+	}
+
+	return nil
+}
+
+// DatapointSubtype finds the definition of the given datapoint subtype.
+func (md *MasterData) DatapointSubtype(dpt DatapointType) *DatapointSubtypeDefinition {
+	def := md.DatapointType(dpt)
+	if def == nil || !dpt.HasSub {
+		return nil
+	}
+
+	for n := range def.Subtypes {
+		if def.Subtypes[n].Type == dpt {
+			return &def.Subtypes[n]
+		}
+	}
+
+	return nil
+}
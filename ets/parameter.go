@@ -0,0 +1,363 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package ets
+
+import "fmt"
+
+// ParameterTypeKind is the kind of values that a parameter type describes.
+type ParameterTypeKind int
+
+const (
+	// ParameterTypeNone is a parameter type without values, e.g. for labels.
+	ParameterTypeNone ParameterTypeKind = iota
+
+	// ParameterTypeNumber describes integers.
+	ParameterTypeNumber
+
+	// ParameterTypeEnumeration describes a list of allowed values.
+	ParameterTypeEnumeration
+
+	// ParameterTypeText describes strings.
+	ParameterTypeText
+
+	// ParameterTypeFloat describes floating-point numbers.
+	ParameterTypeFloat
+
+	// ParameterTypeIPAddress describes IP addresses.
+	ParameterTypeIPAddress
+
+	// ParameterTypeTime describes durations.
+	ParameterTypeTime
+
+	// ParameterTypeDate describes dates.
+	ParameterTypeDate
+
+	// ParameterTypeColor describes colours.
+	ParameterTypeColor
+
+	// ParameterTypePicture describes pictures that are shown to the user.
+	ParameterTypePicture
+
+	// ParameterTypeRawData describes binary data.
+	ParameterTypeRawData
+)
+
+// String returns the name of the kind.
+func (kind ParameterTypeKind) String() string {
+	switch kind {
+	case ParameterTypeNone:
+		return "none"
+
+	case ParameterTypeNumber:
+		return "number"
+
+	case ParameterTypeEnumeration:
+		return "enumeration"
+
+	case ParameterTypeText:
+		return "text"
+
+	case ParameterTypeFloat:
+		return "float"
+
+	case ParameterTypeIPAddress:
+		return "ip-address"
+
+	case ParameterTypeTime:
+		return "time"
+
+	case ParameterTypeDate:
+		return "date"
+
+	case ParameterTypeColor:
+		return "color"
+
+	case ParameterTypePicture:
+		return "picture"
+
+	case ParameterTypeRawData:
+		return "raw-data"
+
+	default:
+		return fmt.Sprintf("kind(%d)", int(kind))
+	}
+}
+
+// ParameterEnumeration is an allowed value of an enumeration parameter type.
+type ParameterEnumeration struct {
+	ID    string
+	Text  string
+	Value string
+}
+
+// ParameterTypeID is the ID of a parameter type.
+type ParameterTypeID string
+
+// ParameterType describes the values of parameters.
+type ParameterType struct {
+	ID        ParameterTypeID
+	Name      string
+	Kind      ParameterTypeKind
+	SizeInBit uint
+
+	// NumberType is the encoding of numbers, e.g. "unsignedInt" or "signedInt".
+	NumberType string
+
+	// Encoding is the encoding of floating-point numbers, e.g. "DPT 9" or "IEEE-754 Single".
+	Encoding string
+
+	// Unit is the unit of durations, e.g. "Seconds".
+	Unit string
+
+	// AddressType is the kind of IP address, e.g. "IPv4".
+	AddressType string
+
+	// Minimum and Maximum limit numbers, floating-point numbers and durations. They are nil if
+	// there is no such limit.
+	Minimum *float64
+	Maximum *float64
+
+	Enumeration []ParameterEnumeration
+}
+
+// ParameterID is the ID of a parameter.
+type ParameterID string
+
+// Parameter is a parameter of an application program.
+type Parameter struct {
+	ID            ParameterID
+	Name          string
+	Text          string
+	ParameterType ParameterTypeID
+	Access        string
+	Value         string
+
+	// DefaultUnionParameter is true if the parameter is the default parameter of its union.
+	DefaultUnionParameter bool
+}
+
+// ParameterUnion is a set of parameters that share the same memory.
+type ParameterUnion struct {
+	SizeInBit  uint
+	Parameters []ParameterID
+}
+
+// ParameterRefID is the ID of a parameter reference.
+type ParameterRefID string
+
+// ParameterRef is a reference to a parameter. Properties that are not nil override those of the
+// parameter.
+type ParameterRef struct {
+	ID     ParameterRefID
+	RefID  ParameterID
+	Name   *string
+	Text   *string
+	Access *string
+	Value  *string
+}
+
+// EffectiveParameter is the effective view of a parameter of a device. Its properties are merged
+// from the ParameterInstanceRef in the project, the ParameterRef and the Parameter in the
+// manufacturer data, in that order of precedence.
+type EffectiveParameter struct {
+	Device *DeviceInstance
+
+	// Instance is nil if the device uses the default value of the parameter.
+	Instance *ParameterInstanceRef
+
+	// Ref, Parameter and Type are nil if the manufacturer data for the parameter is missing.
+	Ref       *ParameterRef
+	Parameter *Parameter
+	Type      *ParameterType
+
+	ID     ParameterRefID
+	Name   string
+	Text   string
+	Access string
+	Value  string
+}
+
+// Resolved determines whether the manufacturer data for the parameter has been found.
+func (ep *EffectiveParameter) Resolved() bool {
+	return ep.Ref != nil && ep.Parameter != nil
+}
+
+// IsDefault determines whether the parameter has its default value.
+func (ep *EffectiveParameter) IsDefault() bool {
+	return ep.Instance == nil
+}
+
+// DisplayValue returns the text of the value for enumerations. Other values are returned as they
+// are.
+func (ep *EffectiveParameter) DisplayValue() string {
+	if ep.Type != nil && ep.Type.Kind == ParameterTypeEnumeration {
+		for _, enum := range ep.Type.Enumeration {
+			if enum.Value == ep.Value {
+				return enum.Text
+			}
+		}
+	}
+
+	return ep.Value
+}
+
+// ParameterResolver resolves the effective parameters of devices using the manufacturer data found
+// in an export archive.
+type ParameterResolver struct {
+	programs          map[ApplicationProgramID]*ApplicationProgram
+	hardware2programs map[Hardware2ProgramID][]ApplicationProgramID
+	refs              map[ParameterRefID]*ParameterRef
+	params            map[ParameterID]*Parameter
+	types             map[ParameterTypeID]*ParameterType
+}
+
+// NewParameterResolver creates a resolver that looks up parameters in the given manufacturer data.
+func NewParameterResolver(manufacturers []*ManufacturerData) *ParameterResolver {
+	r := &ParameterResolver{
+		programs:          map[ApplicationProgramID]*ApplicationProgram{},
+		hardware2programs: map[Hardware2ProgramID][]ApplicationProgramID{},
+		refs:              map[ParameterRefID]*ParameterRef{},
+		params:            map[ParameterID]*Parameter{},
+		types:             map[ParameterTypeID]*ParameterType{},
+	}
+
+	for _, md := range manufacturers {
+		for n := range md.Hardware {
+			for _, h2p := range md.Hardware[n].Hardware2Programs {
+				r.hardware2programs[h2p.ID] = h2p.ApplicationProgramRefs
+			}
+		}
+
+		for n := range md.Programs {
+			prog := &md.Programs[n]
+			r.programs[prog.ID] = prog
+
+			for m := range prog.ParameterTypes {
+				r.types[prog.ParameterTypes[m].ID] = &prog.ParameterTypes[m]
+			}
+
+			for m := range prog.Parameters {
+				r.params[prog.Parameters[m].ID] = &prog.Parameters[m]
+			}
+
+			for m := range prog.ParameterRefs {
+				r.refs[prog.ParameterRefs[m].ID] = &prog.ParameterRefs[m]
+			}
+		}
+	}
+
+	return r
+}
+
+// Programs returns the application programs of the device that are known to the resolver.
+func (r *ParameterResolver) Programs(device *DeviceInstance) []*ApplicationProgram {
+	var programs []*ApplicationProgram
+
+	for _, progID := range r.hardware2programs[device.Hardware2ProgramRefID] {
+		if prog, ok := r.programs[progID]; ok {
+			programs = append(programs, prog)
+		}
+	}
+
+	return programs
+}
+
+// lookupRef finds the parameter reference for the given ID. IDs without the program prefix are
+// resolved using the given programs.
+func (r *ParameterResolver) lookupRef(programs []*ApplicationProgram, id ParameterRefID) *ParameterRef {
+	if ref, ok := r.refs[id]; ok {
+		return ref
+	}
+
+	for _, prog := range programs {
+		if ref, ok := r.refs[ParameterRefID(string(prog.ID)+"_"+string(id))]; ok {
+			return ref
+		}
+	}
+
+	return nil
+}
+
+func (r *ParameterResolver) resolve(
+	device *DeviceInstance,
+	ref *ParameterRef,
+	inst *ParameterInstanceRef,
+) EffectiveParameter {
+	ep := EffectiveParameter{Device: device, Instance: inst, Ref: ref}
+
+	var param Parameter
+	if ref != nil {
+		ep.ID = ref.ID
+		ep.Parameter = r.params[ref.RefID]
+
+		if ep.Parameter != nil {
+			param = *ep.Parameter
+			ep.Type = r.types[param.ParameterType]
+		}
+	} else {
+		ep.ID = inst.RefID
+		ref = &ParameterRef{}
+	}
+
+	var instValue *string
+	if inst != nil {
+		instValue = &inst.Value
+	}
+
+	ep.Name = mergeString(ref.Name, &param.Name)
+	ep.Text = mergeString(ref.Text, &param.Text)
+	ep.Access = mergeString(ref.Access, &param.Access)
+	ep.Value = mergeString(instValue, ref.Value, &param.Value)
+
+	if ep.Access == "" {
+		ep.Access = "ReadWrite"
+	}
+
+	return ep
+}
+
+// ResolveDevice resolves all parameters of the device. These are the parameter references of the
+// device's application programs, followed by parameter instances that could not be resolved.
+func (r *ParameterResolver) ResolveDevice(device *DeviceInstance) []EffectiveParameter {
+	programs := r.Programs(device)
+
+	instances := map[*ParameterRef]*ParameterInstanceRef{}
+	var unresolved []*ParameterInstanceRef
+
+	for n := range device.Parameters {
+		inst := &device.Parameters[n]
+
+		if ref := r.lookupRef(programs, inst.RefID); ref != nil {
+			instances[ref] = inst
+		} else {
+			unresolved = append(unresolved, inst)
+		}
+	}
+
+	var eps []EffectiveParameter
+	seen := map[*ParameterRef]bool{}
+
+	for _, prog := range programs {
+		for n := range prog.ParameterRefs {
+			ref := &prog.ParameterRefs[n]
+			seen[ref] = true
+			eps = append(eps, r.resolve(device, ref, instances[ref]))
+		}
+	}
+
+	// Instances may refer to programs that are not linked to the device.
+	for n := range device.Parameters {
+		inst := &device.Parameters[n]
+
+		if ref := r.lookupRef(programs, inst.RefID); ref != nil && !seen[ref] {
+			eps = append(eps, r.resolve(device, ref, inst))
+		}
+	}
+
+	for _, inst := range unresolved {
+		eps = append(eps, r.resolve(device, nil, inst))
+	}
+
+	return eps
+}
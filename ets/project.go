@@ -7,6 +7,7 @@ import (
 	"encoding/xml"
 	"fmt"
 	"io"
+	"time"
 )
 
 func getNamespace(start xml.StartElement) string {
@@ -25,8 +26,9 @@ type ProjectID string
 // ProjectInfo contains project information. These information are usually stored in
 // the P-XXXX/Project.xml file.
 type ProjectInfo struct {
-	ID   ProjectID
-	Name string
+	ID                ProjectID
+	Name              string
+	GroupAddressStyle GroupAddrStyle
 }
 
 // UnmarshalXML implements xml.Unmarshaler.
@@ -34,7 +36,8 @@ func (pi *ProjectInfo) UnmarshalXML(d *xml.Decoder, start xml.StartElement) erro
 	// Decide which schema to use based on the value of the 'xmlns' attribute.
 	ns := getNamespace(start)
 	switch ns {
-	case schema11Namespace, schema12Namespace, schema13Namespace:
+	case schema11Namespace, schema12Namespace, schema13Namespace, schema14Namespace,
+		schema20Namespace, schema21Namespace:
 		return d.DecodeElement((*projectInfo11)(pi), &start)
 
 	default:
@@ -42,6 +45,11 @@ func (pi *ProjectInfo) UnmarshalXML(d *xml.Decoder, start xml.StartElement) erro
 	}
 }
 
+// FormatGroupAddr formats the group address using the style configured for the project.
+func (pi *ProjectInfo) FormatGroupAddr(addr GroupAddr) string {
+	return addr.Format(pi.GroupAddressStyle)
+}
+
 // DecodeProjectInfo parses the contents of project info file.
 func DecodeProjectInfo(r io.Reader) (*ProjectInfo, error) {
 	info := &ProjectInfo{}
@@ -62,10 +70,49 @@ type Connector struct {
 type ComObjectRefID string
 
 // ComObjectInstanceRef connects a communication object reference with zero or more group addresses.
+// Properties that are not nil override those of the referenced communication object.
 type ComObjectInstanceRef struct {
-	RefID         ComObjectRefID
-	DatapointType string
-	Connectors    []Connector
+	RefID             ComObjectRefID
+	DatapointType     string
+	Text              *string
+	Description       *string
+	FunctionText      *string
+	Priority          *string
+	ReadFlag          *bool
+	WriteFlag         *bool
+	CommunicationFlag *bool
+	TransmitFlag      *bool
+	UpdateFlag        *bool
+	ReadOnInitFlag    *bool
+	Connectors        []Connector
+}
+
+// ParameterInstanceRef is the value of a parameter of a device.
+type ParameterInstanceRef struct {
+	RefID ParameterRefID
+	Value string
+}
+
+// IPConfig is the IP configuration of a device.
+type IPConfig struct {
+	// Assign is either "Fixed" or "Auto". Automatically assigned devices obtain their address via
+	// DHCP or AutoIP.
+	Assign         string
+	IPAddress      string
+	SubnetMask     string
+	DefaultGateway string
+	MACAddress     string
+}
+
+// BusInterface is an interface through which clients can access the bus via the device, e.g. a
+// tunnel of an IP interface.
+type BusInterface struct {
+	RefID        string
+	AddressIndex uint
+	AccessType   string
+	Name         string
+	Comment      string
+	Password     string
 }
 
 // DeviceInstanceID is the ID of a device instance.
@@ -73,21 +120,88 @@ type DeviceInstanceID string
 
 // DeviceInstance is a device instance.
 type DeviceInstance struct {
-	ID         DeviceInstanceID
-	Name       string
-	Address    uint
+	ID                    DeviceInstanceID
+	Name                  string
+	Address               DeviceNumber
+	HasAddress            bool
+	ProductRefID          ProductID
+	Hardware2ProgramRefID Hardware2ProgramID
+	SerialNumber          string
+	Description           string
+	Comment               string
+	InstallationHints     string
+	IsActivityCalculated  bool
+
+	// LastModified and LastDownload are zero if they are not known.
+	LastModified time.Time
+	LastDownload time.Time
+
+	// These flags indicate which parts of the device have been downloaded.
+	IndividualAddressLoaded  bool
+	ApplicationProgramLoaded bool
+	ParametersLoaded         bool
+	CommunicationPartLoaded  bool
+	MediumConfigLoaded       bool
+
 	ComObjects []ComObjectInstanceRef
+	Parameters []ParameterInstanceRef
+
+	// IPConfig is nil unless the device is an IP device.
+	IPConfig *IPConfig
+
+	// AdditionalAddresses are further device addresses of the device on its line, e.g. for the
+	// tunnels of an IP interface.
+	AdditionalAddresses []DeviceNumber
+	BusInterfaces       []BusInterface
+}
+
+// IsCoupler determines whether the device is a coupler, i.e. it has the device address 0 within
+// its line. IP routers are couplers that have an IP configuration.
+func (di *DeviceInstance) IsCoupler() bool {
+	return di.HasAddress && di.Address == 0
+}
+
+// DownloadPending determines whether the individual address, the application program, the
+// parameters or the communication part of the device still need to be downloaded.
+func (di *DeviceInstance) DownloadPending() bool {
+	return !di.IndividualAddressLoaded || !di.ApplicationProgramLoaded || !di.ParametersLoaded ||
+		!di.CommunicationPartLoaded
 }
 
 // LineID is the ID of a line.
 type LineID string
 
+// SegmentID is the ID of a segment.
+type SegmentID string
+
+// Segment is a part of a line that uses a single medium. Only ETS6 divides lines into segments.
+type Segment struct {
+	ID            SegmentID
+	Name          string
+	Number        uint
+	MediumType    MediumTypeID
+	DomainAddress string
+	Devices       []DeviceInstanceID
+}
+
 // Line is a line.
 type Line struct {
 	ID      LineID
 	Name    string
-	Address uint
-	Devices []DeviceInstance
+	Address LineAddr
+
+	// MediumType is the medium of the line, e.g. MediumTP. DomainAddress is the domain address of
+	// power-line and radio-frequency lines.
+	MediumType    MediumTypeID
+	DomainAddress string
+
+	// AdditionalGroupAddresses are passed by the coupler of the line even if no device within the
+	// line uses them.
+	AdditionalGroupAddresses []GroupAddr
+
+	// Devices contains the devices of all segments.
+	Devices  []DeviceInstance
+	Segments []Segment
 }
 
 // AreaID is the ID of an area.
@@ -97,18 +211,45 @@ type AreaID string
 type Area struct {
 	ID      AreaID
 	Name    string
-	Address uint
+	Address AreaAddr
 	Lines   []Line
 }
 
 // GroupAddressID is the ID of a group address.
 type GroupAddressID string
 
+// GroupAddressSecurity determines whether telegrams sent to a group address are secured.
+type GroupAddressSecurity string
+
+// These are the security modes of group addresses.
+const (
+	// GroupAddressSecurityAuto secures the group address if all connected devices support it.
+	GroupAddressSecurityAuto GroupAddressSecurity = "Auto"
+
+	GroupAddressSecurityOn  GroupAddressSecurity = "On"
+	GroupAddressSecurityOff GroupAddressSecurity = "Off"
+)
+
 // GroupAddress is a group address.
 type GroupAddress struct {
-	ID      GroupAddressID
-	Name    string
-	Address uint
+	ID            GroupAddressID
+	Name          string
+	Address       GroupAddr
+	Description   string
+	Comment       string
+	DatapointType string
+
+	// Central marks group addresses that concern the entire installation. Unfiltered group
+	// addresses are passed by couplers without being filtered.
+	Central    bool
+	Unfiltered bool
+
+	Security GroupAddressSecurity
+	Puid     uint
+
+	// Key is the base64-encoded key which secures the group address. It is empty unless the group
+	// address is secured.
+	Key string
 }
 
 // GroupRangeID is the ID of a group range.
@@ -116,12 +257,100 @@ type GroupRangeID string
 
 // GroupRange is a range of group addresses.
 type GroupRange struct {
-	ID         GroupRangeID
-	Name       string
-	RangeStart uint
-	RangeEnd   uint
-	Addresses  []GroupAddress
-	SubRanges  []GroupRange
+	ID          GroupRangeID
+	Name        string
+	RangeStart  GroupAddr
+	RangeEnd    GroupAddr
+	Description string
+	Comment     string
+	Unfiltered  bool
+	Addresses   []GroupAddress
+	SubRanges   []GroupRange
+}
+
+// LocationType is the kind of a location.
+type LocationType string
+
+// These are the kinds of locations.
+const (
+	LocationBuilding          LocationType = "Building"
+	LocationBuildingPart      LocationType = "BuildingPart"
+	LocationFloor             LocationType = "Floor"
+	LocationRoom              LocationType = "Room"
+	LocationDistributionBoard LocationType = "DistributionBoard"
+	LocationCorridor          LocationType = "Corridor"
+	LocationStairway          LocationType = "Stairway"
+)
+
+// LocationID is the ID of a location.
+type LocationID string
+
+// Location is a building or a part of a building.
+type Location struct {
+	ID           LocationID
+	Name         string
+	Type         LocationType
+	Number       string
+	Description  string
+	Comment      string
+	Devices      []DeviceInstanceID
+	Functions    []Function
+	SubLocations []Location
+}
+
+// FunctionType is the kind of a function.
+type FunctionType string
+
+// These are some of the kinds of functions. Newer schemas may refer to the function types of the
+// master data by their ID (e.g. "FT-1") instead.
+const (
+	FunctionCustom          FunctionType = "Custom"
+	FunctionSwitchableLight FunctionType = "SwitchableLight"
+	FunctionDimmableLight   FunctionType = "DimmableLight"
+	FunctionSunblind        FunctionType = "Sunblind"
+	FunctionHeatingRadiator FunctionType = "HeatingRadiator"
+	FunctionHeatingFloor    FunctionType = "HeatingFloor"
+)
+
+// GroupAddressRefID is the ID of a group address reference.
+type GroupAddressRefID string
+
+// GroupAddressRef attaches a group address to a function. The role describes the purpose of the
+// group address within the function, e.g. switching or reporting the status.
+type GroupAddressRef struct {
+	ID    GroupAddressRefID
+	Name  string
+	RefID GroupAddressID
+	Role  string
+}
+
+// FunctionID is the ID of a function.
+type FunctionID string
+
+// Function groups the group addresses which together control something within a location, e.g. a
+// light or a sunblind.
+type Function struct {
+	ID               FunctionID
+	Name             string
+	Type             FunctionType
+	Number           string
+	Description      string
+	Comment          string
+	GroupAddressRefs []GroupAddressRef
+}
+
+// TradeID is the ID of a trade.
+type TradeID string
+
+// Trade groups devices by their trade, e.g. lighting or heating.
+type Trade struct {
+	ID          TradeID
+	Name        string
+	Number      string
+	Description string
+	Comment     string
+	Devices     []DeviceInstanceID
+	SubTrades   []Trade
 }
 
 // Installation is an installation within a project.
@@ -129,6 +358,8 @@ type Installation struct {
 	Name           string
 	Topology       []Area
 	GroupAddresses []GroupRange
+	Locations      []Location
+	Trades         []Trade
 }
 
 // Project contains an entire project. These information are usually stored within a file located
@@ -143,9 +374,12 @@ func (p *Project) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 	// Decide which schema to use based on the value of the 'xmlns' attribute.
 	ns := getNamespace(start)
 	switch ns {
-	case schema11Namespace, schema12Namespace, schema13Namespace:
+	case schema11Namespace, schema12Namespace, schema13Namespace, schema14Namespace:
 		return d.DecodeElement((*project11)(p), &start)
 
+	case schema20Namespace, schema21Namespace:
+		return d.DecodeElement((*project21)(p), &start)
+
 	default:
 		return fmt.Errorf("Unexpected namespace '%s'", ns)
 	}
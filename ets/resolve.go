@@ -0,0 +1,202 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package ets
+
+import "strings"
+
+// EffectiveComObject is the effective view of a communication object of a device. Its properties
+// are merged from the ComObjectInstanceRef in the project, the ComObjectRef and the ComObject in
+// the manufacturer data, in that order of precedence.
+type EffectiveComObject struct {
+	Device   *DeviceInstance
+	Instance *ComObjectInstanceRef
+
+	// Ref and Object are nil if the manufacturer data for the communication object is missing.
+	Ref    *ComObjectRef
+	Object *ComObject
+
+	Name              string
+	Text              string
+	Description       string
+	FunctionText      string
+	ObjectSize        string
+	DatapointType     string
+	Priority          string
+	ReadFlag          bool
+	WriteFlag         bool
+	CommunicationFlag bool
+	TransmitFlag      bool
+	UpdateFlag        bool
+	ReadOnInitFlag    bool
+	Connectors        []Connector
+}
+
+// Resolved determines whether the manufacturer data for the communication object has been found.
+func (eco *EffectiveComObject) Resolved() bool {
+	return eco.Ref != nil && eco.Object != nil
+}
+
+// ComObjectResolver resolves the effective properties of communication objects using the
+// manufacturer data found in an export archive.
+type ComObjectResolver struct {
+	refs      map[ComObjectRefID]*ComObjectRef
+	shortRefs map[ComObjectRefID][]*ComObjectRef
+	objects   map[ComObjectID]*ComObject
+	programs  map[Hardware2ProgramID][]ApplicationProgramID
+}
+
+// NewComObjectResolver creates a resolver that looks up communication objects in the given
+// manufacturer data.
+func NewComObjectResolver(manufacturers []*ManufacturerData) *ComObjectResolver {
+	r := &ComObjectResolver{
+		refs:      map[ComObjectRefID]*ComObjectRef{},
+		shortRefs: map[ComObjectRefID][]*ComObjectRef{},
+		objects:   map[ComObjectID]*ComObject{},
+		programs:  map[Hardware2ProgramID][]ApplicationProgramID{},
+	}
+
+	for _, md := range manufacturers {
+		for n := range md.Hardware {
+			for _, h2p := range md.Hardware[n].Hardware2Programs {
+				r.programs[h2p.ID] = h2p.ApplicationProgramRefs
+			}
+		}
+
+		for n := range md.Programs {
+			prog := &md.Programs[n]
+
+			for m := range prog.Objects {
+				r.objects[prog.Objects[m].ID] = &prog.Objects[m]
+			}
+
+			for m := range prog.ObjectRefs {
+				ref := &prog.ObjectRefs[m]
+				r.refs[ref.ID] = ref
+
+				// Newer projects refer to communication objects without the program prefix.
+				prefix := string(prog.ID) + "_"
+				if strings.HasPrefix(string(ref.ID), prefix) {
+					shortID := ComObjectRefID(strings.TrimPrefix(string(ref.ID), prefix))
+					r.shortRefs[shortID] = append(r.shortRefs[shortID], ref)
+				}
+			}
+		}
+	}
+
+	return r
+}
+
+// lookupRef finds the communication object reference for the given ID. IDs without the program
+// prefix are resolved using the application programs of the device. Failing that, they can only
+// be resolved if they are unambiguous.
+func (r *ComObjectResolver) lookupRef(device *DeviceInstance, id ComObjectRefID) *ComObjectRef {
+	if ref, ok := r.refs[id]; ok {
+		return ref
+	}
+
+	for _, progID := range r.programs[device.Hardware2ProgramRefID] {
+		if ref, ok := r.refs[ComObjectRefID(string(progID)+"_"+string(id))]; ok {
+			return ref
+		}
+	}
+
+	if refs := r.shortRefs[id]; len(refs) == 1 {
+		return refs[0]
+	}
+
+	return nil
+}
+
+func mergeString(values ...*string) string {
+	for _, value := range values {
+		if value != nil {
+			return *value
+		}
+	}
+
+	return ""
+}
+
+func mergeFlag(values ...*bool) bool {
+	for _, value := range values {
+		if value != nil {
+			return *value
+		}
+	}
+
+	return false
+}
+
+// Resolve the effective properties of a communication object of the given device.
+func (r *ComObjectResolver) Resolve(device *DeviceInstance, inst *ComObjectInstanceRef) EffectiveComObject {
+	eco := EffectiveComObject{
+		Device:     device,
+		Instance:   inst,
+		Ref:        r.lookupRef(device, inst.RefID),
+		Connectors: inst.Connectors,
+	}
+
+	var ref ComObjectRef
+	if eco.Ref != nil {
+		ref = *eco.Ref
+		eco.Object = r.objects[ref.RefID]
+	}
+
+	var obj ComObject
+	if eco.Object != nil {
+		obj = *eco.Object
+	}
+
+	var instDatapointType *string
+	if inst.DatapointType != "" {
+		instDatapointType = &inst.DatapointType
+	}
+
+	eco.Name = mergeString(ref.Name, &obj.Name)
+	eco.Text = mergeString(inst.Text, ref.Text, &obj.Text)
+	eco.Description = mergeString(inst.Description, ref.Description, &obj.Description)
+	eco.FunctionText = mergeString(inst.FunctionText, ref.FunctionText, &obj.FunctionText)
+	eco.ObjectSize = mergeString(ref.ObjectSize, &obj.ObjectSize)
+	eco.DatapointType = mergeString(instDatapointType, ref.DatapointType, &obj.DatapointType)
+	eco.Priority = mergeString(inst.Priority, ref.Priority, &obj.Priority)
+	eco.ReadFlag = mergeFlag(inst.ReadFlag, ref.ReadFlag, &obj.ReadFlag)
+	eco.WriteFlag = mergeFlag(inst.WriteFlag, ref.WriteFlag, &obj.WriteFlag)
+	eco.CommunicationFlag = mergeFlag(inst.CommunicationFlag, ref.CommunicationFlag, &obj.CommunicationFlag)
+	eco.TransmitFlag = mergeFlag(inst.TransmitFlag, ref.TransmitFlag, &obj.TransmitFlag)
+	eco.UpdateFlag = mergeFlag(inst.UpdateFlag, ref.UpdateFlag, &obj.UpdateFlag)
+	eco.ReadOnInitFlag = mergeFlag(inst.ReadOnInitFlag, ref.ReadOnInitFlag, &obj.ReadOnInitFlag)
+
+	return eco
+}
+
+// ResolveDevice resolves the effective properties of all communication objects of the device.
+func (r *ComObjectResolver) ResolveDevice(device *DeviceInstance) []EffectiveComObject {
+	ecos := make([]EffectiveComObject, len(device.ComObjects))
+
+	for n := range device.ComObjects {
+		ecos[n] = r.Resolve(device, &device.ComObjects[n])
+	}
+
+	return ecos
+}
+
+// ResolveProject resolves the effective properties of all communication objects of all devices
+// within the project.
+func (r *ComObjectResolver) ResolveProject(proj *Project) map[DeviceInstanceID][]EffectiveComObject {
+	result := map[DeviceInstanceID][]EffectiveComObject{}
+
+	for i := range proj.Installations {
+		proj.Installations[i].WalkDevices(func(area *Area, line *Line, device *DeviceInstance) {
+			result[device.ID] = r.ResolveDevice(device)
+		})
+	}
+
+	return result
+}
+
+// ResolveComObjects resolves the effective properties of all communication objects within the
+// project using the given manufacturer data.
+func ResolveComObjects(proj *Project, manufacturers []*ManufacturerData) map[DeviceInstanceID][]EffectiveComObject {
+	return NewComObjectResolver(manufacturers).ResolveProject(proj)
+}
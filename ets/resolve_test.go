@@ -0,0 +1,161 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package ets
+
+import "testing"
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+// testResolverManufacturers contains two application programs. Both define the communication
+// object reference O-1_R-1, only the first one defines O-2_R-2.
+func testResolverManufacturers() []*ManufacturerData {
+	object := func(id ComObjectID) ComObject {
+		return ComObject{
+			ID:            id,
+			Name:          "Object",
+			Text:          "Object text",
+			Description:   "Object description",
+			FunctionText:  "Object function",
+			ObjectSize:    "1 Bit",
+			DatapointType: "DPST-1-1",
+			Priority:      "Low",
+			WriteFlag:     true,
+			TransmitFlag:  true,
+		}
+	}
+
+	return []*ManufacturerData{{
+		Manufacturer: "M-0083",
+		Hardware: []Hardware{{
+			ID: "M-0083_H-1",
+			Hardware2Programs: []Hardware2Program{
+				{ID: "M-0083_H-1_HP-1", ApplicationProgramRefs: []ApplicationProgramID{"M-0083_A-1"}},
+				{ID: "M-0083_H-1_HP-2", ApplicationProgramRefs: []ApplicationProgramID{"M-0083_A-2"}},
+			},
+		}},
+		Programs: []ApplicationProgram{
+			{
+				ID:      "M-0083_A-1",
+				Objects: []ComObject{object("M-0083_A-1_O-1"), object("M-0083_A-1_O-2")},
+				ObjectRefs: []ComObjectRef{
+					{
+						ID:           "M-0083_A-1_O-1_R-1",
+						RefID:        "M-0083_A-1_O-1",
+						Text:         stringPtr("Ref text"),
+						FunctionText: stringPtr("Ref function"),
+						Priority:     stringPtr("High"),
+						ReadFlag:     boolPtr(true),
+						WriteFlag:    boolPtr(false),
+					},
+					{ID: "M-0083_A-1_O-2_R-2", RefID: "M-0083_A-1_O-2"},
+				},
+			},
+			{
+				ID:      "M-0083_A-2",
+				Objects: []ComObject{object("M-0083_A-2_O-1")},
+				ObjectRefs: []ComObjectRef{
+					{ID: "M-0083_A-2_O-1_R-1", RefID: "M-0083_A-2_O-1", Text: stringPtr("Second program")},
+				},
+			},
+		},
+	}}
+}
+
+func TestResolveMerge(t *testing.T) {
+	resolver := NewComObjectResolver(testResolverManufacturers())
+
+	device := &DeviceInstance{ID: "DI-1"}
+	inst := &ComObjectInstanceRef{
+		RefID:         "M-0083_A-1_O-1_R-1",
+		Text:          stringPtr("Instance text"),
+		DatapointType: "DPST-1-2",
+		WriteFlag:     boolPtr(true),
+	}
+
+	eco := resolver.Resolve(device, inst)
+	if !eco.Resolved() {
+		t.Fatal("Communication object has not been resolved")
+	}
+
+	texts := []struct {
+		name, got, want string
+	}{
+		// The instance takes precedence over the reference and the object.
+		{"Text", eco.Text, "Instance text"},
+		{"DatapointType", eco.DatapointType, "DPST-1-2"},
+
+		// The reference takes precedence over the object.
+		{"FunctionText", eco.FunctionText, "Ref function"},
+		{"Priority", eco.Priority, "High"},
+
+		// The object provides the defaults.
+		{"Name", eco.Name, "Object"},
+		{"Description", eco.Description, "Object description"},
+		{"ObjectSize", eco.ObjectSize, "1 Bit"},
+	}
+
+	for _, test := range texts {
+		if test.got != test.want {
+			t.Errorf("%s is %q, want %q", test.name, test.got, test.want)
+		}
+	}
+
+	flags := []struct {
+		name      string
+		got, want bool
+	}{
+		{"ReadFlag", eco.ReadFlag, true},
+		{"WriteFlag", eco.WriteFlag, true},
+		{"CommunicationFlag", eco.CommunicationFlag, false},
+		{"TransmitFlag", eco.TransmitFlag, true},
+		{"UpdateFlag", eco.UpdateFlag, false},
+	}
+
+	for _, test := range flags {
+		if test.got != test.want {
+			t.Errorf("%s is %v, want %v", test.name, test.got, test.want)
+		}
+	}
+}
+
+func TestResolveShortRefID(t *testing.T) {
+	resolver := NewComObjectResolver(testResolverManufacturers())
+
+	tests := []struct {
+		h2p      Hardware2ProgramID
+		refID    ComObjectRefID
+		wantText string
+	}{
+		// The application program of the device decides between ambiguous short IDs.
+		{"M-0083_H-1_HP-1", "O-1_R-1", "Ref text"},
+		{"M-0083_H-1_HP-2", "O-1_R-1", "Second program"},
+
+		// Ambiguous short IDs cannot be resolved without the application program.
+		{"", "O-1_R-1", ""},
+
+		// Unambiguous short IDs can.
+		{"", "O-2_R-2", "Object text"},
+
+		{"M-0083_H-1_HP-1", "O-9_R-9", ""},
+	}
+
+	for _, test := range tests {
+		device := &DeviceInstance{ID: "DI-1", Hardware2ProgramRefID: test.h2p}
+		eco := resolver.Resolve(device, &ComObjectInstanceRef{RefID: test.refID})
+
+		if eco.Resolved() != (test.wantText != "") {
+			t.Errorf("%s with %q: Resolved() = %v", test.refID, test.h2p, eco.Resolved())
+		}
+
+		if eco.Text != test.wantText {
+			t.Errorf("%s with %q: got text %q, want %q", test.refID, test.h2p, eco.Text, test.wantText)
+		}
+	}
+}
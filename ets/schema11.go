@@ -3,7 +3,12 @@
 
 package ets
 
-import "encoding/xml"
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+)
 
 const schema11Namespace = "http://knx.org/xml/project/11"
 
@@ -14,7 +19,8 @@ func (pi *projectInfo11) UnmarshalXML(d *xml.Decoder, start xml.StartElement) er
 		Project struct {
 			ID                 string `xml:"Id,attr"`
 			ProjectInformation struct {
-				Name string `xml:",attr"`
+				Name              string `xml:",attr"`
+				GroupAddressStyle string `xml:",attr"`
 			}
 		}
 	}
@@ -25,66 +31,244 @@ func (pi *projectInfo11) UnmarshalXML(d *xml.Decoder, start xml.StartElement) er
 
 	pi.ID = ProjectID(doc.Project.ID)
 	pi.Name = doc.Project.ProjectInformation.Name
+	pi.GroupAddressStyle = parseGroupAddrStyle(doc.Project.ProjectInformation.GroupAddressStyle)
 
 	return nil
 }
 
-type deviceInstance11 DeviceInstance
+// parseFlag interprets the value of an optional flag attribute.
+func parseFlag(value *string) *bool {
+	if value == nil {
+		return nil
+	}
 
-func (di *deviceInstance11) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	flag := *value == "Enabled"
+	return &flag
+}
+
+type comObjectInstanceRef11 ComObjectInstanceRef
+
+func (cir *comObjectInstanceRef11) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 	var doc struct {
-		ID         string `xml:"Id,attr"`
-		Name       string `xml:",attr"`
-		Address    uint   `xml:",attr"`
-		ComObjects []struct {
-			RefID         string `xml:"RefId,attr"`
-			DatapointType string `xml:",attr"`
-			Connectors    struct {
-				Elements []struct {
-					XMLName xml.Name
-					RefID   string `xml:"GroupAddressRefId,attr"`
-				} `xml:",any"`
-			}
-		} `xml:"ComObjectInstanceRefs>ComObjectInstanceRef"`
+		RefID             string  `xml:"RefId,attr"`
+		DatapointType     string  `xml:",attr"`
+		Text              *string `xml:",attr"`
+		Description       *string `xml:",attr"`
+		FunctionText      *string `xml:",attr"`
+		Priority          *string `xml:",attr"`
+		ReadFlag          *string `xml:",attr"`
+		WriteFlag         *string `xml:",attr"`
+		CommunicationFlag *string `xml:",attr"`
+		TransmitFlag      *string `xml:",attr"`
+		UpdateFlag        *string `xml:",attr"`
+		ReadOnInitFlag    *string `xml:",attr"`
+		Connectors        struct {
+			Elements []struct {
+				XMLName xml.Name
+				RefID   string `xml:"GroupAddressRefId,attr"`
+			} `xml:",any"`
+		}
 	}
 
 	if err := d.DecodeElement(&doc, &start); err != nil {
 		return err
 	}
 
-	di.ID = DeviceInstanceID(doc.ID)
-	di.Name = doc.Name
-	di.Address = doc.Address
-	di.ComObjects = make([]ComObjectInstanceRef, len(doc.ComObjects))
+	cir.RefID = ComObjectRefID(doc.RefID)
+	cir.DatapointType = doc.DatapointType
+	cir.Text = doc.Text
+	cir.Description = doc.Description
+	cir.FunctionText = doc.FunctionText
+	cir.Priority = doc.Priority
+	cir.ReadFlag = parseFlag(doc.ReadFlag)
+	cir.WriteFlag = parseFlag(doc.WriteFlag)
+	cir.CommunicationFlag = parseFlag(doc.CommunicationFlag)
+	cir.TransmitFlag = parseFlag(doc.TransmitFlag)
+	cir.UpdateFlag = parseFlag(doc.UpdateFlag)
+	cir.ReadOnInitFlag = parseFlag(doc.ReadOnInitFlag)
+	cir.Connectors = make([]Connector, len(doc.Connectors.Elements))
+
+	for n, docConnElem := range doc.Connectors.Elements {
+		cir.Connectors[n] = Connector{
+			Receive: docConnElem.XMLName.Local == "Receive",
+			RefID:   GroupAddressID(docConnElem.RefID),
+		}
+	}
 
-	for n, docComObj := range doc.ComObjects {
-		comObj := ComObjectInstanceRef{
-			RefID:         ComObjectRefID(docComObj.RefID),
-			DatapointType: docComObj.DatapointType,
-			Connectors:    make([]Connector, len(docComObj.Connectors.Elements)),
+	return nil
+}
+
+// parseTimestamp parses an optional timestamp attribute. Timestamps without a time zone are
+// assumed to be in UTC.
+func parseTimestamp(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05.999999999"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
 		}
+	}
 
-		for m, docConnElem := range docComObj.Connectors.Elements {
-			comObj.Connectors[m] = Connector{
-				Receive: docConnElem.XMLName.Local == "Receive",
-				RefID:   GroupAddressID(docConnElem.RefID),
-			}
+	return time.Time{}, fmt.Errorf("Invalid timestamp '%s'", value)
+}
+
+// deviceInstanceAttrs contains the attributes and parameters of a device instance.
+type deviceInstanceAttrs struct {
+	ID                       string `xml:"Id,attr"`
+	Name                     string `xml:",attr"`
+	Address                  *uint  `xml:",attr"`
+	ProductRefID             string `xml:"ProductRefId,attr"`
+	Hardware2ProgramRefID    string `xml:"Hardware2ProgramRefId,attr"`
+	SerialNumber             string `xml:",attr"`
+	Description              string `xml:",attr"`
+	Comment                  string `xml:",attr"`
+	InstallationHints        string `xml:",attr"`
+	IsActivityCalculated     bool   `xml:",attr"`
+	LastModified             string `xml:",attr"`
+	LastDownload             string `xml:",attr"`
+	IndividualAddressLoaded  bool   `xml:",attr"`
+	ApplicationProgramLoaded bool   `xml:",attr"`
+	ParametersLoaded         bool   `xml:",attr"`
+	CommunicationPartLoaded  bool   `xml:",attr"`
+	MediumConfigLoaded       bool   `xml:",attr"`
+	Parameters               []struct {
+		RefID string `xml:"RefId,attr"`
+		Value string `xml:",attr"`
+	} `xml:"ParameterInstanceRefs>ParameterInstanceRef"`
+	IPConfig *struct {
+		Assign         string `xml:",attr"`
+		IPAddress      string `xml:",attr"`
+		SubnetMask     string `xml:",attr"`
+		DefaultGateway string `xml:",attr"`
+		MACAddress     string `xml:",attr"`
+	}
+	AdditionalAddresses []struct {
+		Address uint `xml:",attr"`
+	} `xml:"AdditionalAddresses>Address"`
+	BusInterfaces []struct {
+		RefID        string `xml:"RefId,attr"`
+		AddressIndex uint   `xml:",attr"`
+		AccessType   string `xml:",attr"`
+		Name         string `xml:",attr"`
+		Comment      string `xml:",attr"`
+		Password     string `xml:",attr"`
+	} `xml:"BusInterfaces>BusInterface"`
+}
+
+// apply copies the attributes into the device instance.
+func (attrs *deviceInstanceAttrs) apply(di *DeviceInstance) (err error) {
+	di.ID = DeviceInstanceID(attrs.ID)
+	di.Name = attrs.Name
+	di.HasAddress = attrs.Address != nil
+	di.ProductRefID = ProductID(attrs.ProductRefID)
+	di.Hardware2ProgramRefID = Hardware2ProgramID(attrs.Hardware2ProgramRefID)
+	di.SerialNumber = attrs.SerialNumber
+	di.Description = attrs.Description
+	di.Comment = attrs.Comment
+	di.InstallationHints = attrs.InstallationHints
+	di.IsActivityCalculated = attrs.IsActivityCalculated
+	di.IndividualAddressLoaded = attrs.IndividualAddressLoaded
+	di.ApplicationProgramLoaded = attrs.ApplicationProgramLoaded
+	di.ParametersLoaded = attrs.ParametersLoaded
+	di.CommunicationPartLoaded = attrs.CommunicationPartLoaded
+	di.MediumConfigLoaded = attrs.MediumConfigLoaded
+
+	if attrs.Address != nil {
+		di.Address = DeviceNumber(*attrs.Address)
+	}
+
+	di.Parameters = make([]ParameterInstanceRef, len(attrs.Parameters))
+
+	for n, docParam := range attrs.Parameters {
+		di.Parameters[n] = ParameterInstanceRef{
+			RefID: ParameterRefID(docParam.RefID),
+			Value: docParam.Value,
 		}
+	}
+
+	if attrs.IPConfig != nil {
+		di.IPConfig = &IPConfig{
+			Assign:         attrs.IPConfig.Assign,
+			IPAddress:      attrs.IPConfig.IPAddress,
+			SubnetMask:     attrs.IPConfig.SubnetMask,
+			DefaultGateway: attrs.IPConfig.DefaultGateway,
+			MACAddress:     attrs.IPConfig.MACAddress,
+		}
+	}
+
+	for _, docAddr := range attrs.AdditionalAddresses {
+		di.AdditionalAddresses = append(di.AdditionalAddresses, DeviceNumber(docAddr.Address))
+	}
+
+	for _, docBusInterface := range attrs.BusInterfaces {
+		di.BusInterfaces = append(di.BusInterfaces, BusInterface(docBusInterface))
+	}
+
+	if di.LastModified, err = parseTimestamp(attrs.LastModified); err != nil {
+		return
+	}
+
+	di.LastDownload, err = parseTimestamp(attrs.LastDownload)
+	return
+}
+
+type deviceInstance11 DeviceInstance
 
-		di.ComObjects[n] = comObj
+func (di *deviceInstance11) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var doc struct {
+		deviceInstanceAttrs
+		ComObjects []comObjectInstanceRef11 `xml:"ComObjectInstanceRefs>ComObjectInstanceRef"`
+	}
 
+	if err := d.DecodeElement(&doc, &start); err != nil {
+		return err
+	}
+
+	if err := doc.apply((*DeviceInstance)(di)); err != nil {
+		return err
+	}
+
+	di.ComObjects = make([]ComObjectInstanceRef, len(doc.ComObjects))
+
+	for n, docComObj := range doc.ComObjects {
+		di.ComObjects[n] = ComObjectInstanceRef(docComObj)
 	}
 
 	return nil
 }
 
+// lineAttrs contains the attributes of a line.
+type lineAttrs struct {
+	ID                       string `xml:"Id,attr"`
+	Name                     string `xml:",attr"`
+	Address                  uint   `xml:",attr"`
+	MediumTypeRefID          string `xml:"MediumTypeRefId,attr"`
+	DomainAddress            string `xml:",attr"`
+	AdditionalGroupAddresses []struct {
+		Address uint `xml:",attr"`
+	} `xml:"AdditionalGroupAddresses>GroupAddress"`
+}
+
+// apply copies the attributes into the line.
+func (attrs *lineAttrs) apply(l *Line) {
+	l.ID = LineID(attrs.ID)
+	l.Name = attrs.Name
+	l.Address = LineAddr(attrs.Address)
+	l.MediumType = MediumTypeID(attrs.MediumTypeRefID)
+	l.DomainAddress = attrs.DomainAddress
+
+	for _, docAddr := range attrs.AdditionalGroupAddresses {
+		l.AdditionalGroupAddresses = append(l.AdditionalGroupAddresses, GroupAddr(docAddr.Address))
+	}
+}
+
 type line11 Line
 
 func (l *line11) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 	var doc struct {
-		ID             string `xml:"Id,attr"`
-		Name           string `xml:",attr"`
-		Address        uint   `xml:",attr"`
+		lineAttrs
 		DeviceInstance []deviceInstance11
 	}
 
@@ -92,9 +276,7 @@ func (l *line11) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 		return err
 	}
 
-	l.ID = LineID(doc.ID)
-	l.Name = doc.Name
-	l.Address = doc.Address
+	doc.apply((*Line)(l))
 	l.Devices = make([]DeviceInstance, len(doc.DeviceInstance))
 
 	for n, docDeviceInstance := range doc.DeviceInstance {
@@ -120,7 +302,7 @@ func (a *area11) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 
 	a.ID = AreaID(doc.ID)
 	a.Name = doc.Name
-	a.Address = doc.Address
+	a.Address = AreaAddr(doc.Address)
 	a.Lines = make([]Line, len(doc.Line))
 
 	for n, docLine := range doc.Line {
@@ -138,10 +320,21 @@ func (gar *groupRange11) UnmarshalXML(d *xml.Decoder, start xml.StartElement) er
 		Name         string `xml:",attr"`
 		RangeStart   uint   `xml:",attr"`
 		RangeEnd     uint   `xml:",attr"`
+		Description  string `xml:",attr"`
+		Comment      string `xml:",attr"`
+		Unfiltered   bool   `xml:",attr"`
 		GroupAddress []struct {
-			ID      string `xml:"Id,attr"`
-			Name    string `xml:",attr"`
-			Address uint   `xml:",attr"`
+			ID            string `xml:"Id,attr"`
+			Name          string `xml:",attr"`
+			Address       uint   `xml:",attr"`
+			Description   string `xml:",attr"`
+			Comment       string `xml:",attr"`
+			DatapointType string `xml:",attr"`
+			Central       bool   `xml:",attr"`
+			Unfiltered    bool   `xml:",attr"`
+			Security      string `xml:",attr"`
+			Puid          uint   `xml:",attr"`
+			Key           string `xml:",attr"`
 		}
 		GroupRange []groupRange11
 	}
@@ -152,16 +345,33 @@ func (gar *groupRange11) UnmarshalXML(d *xml.Decoder, start xml.StartElement) er
 
 	gar.ID = GroupRangeID(doc.ID)
 	gar.Name = doc.Name
-	gar.RangeStart = doc.RangeStart
-	gar.RangeEnd = doc.RangeEnd
+	gar.RangeStart = GroupAddr(doc.RangeStart)
+	gar.RangeEnd = GroupAddr(doc.RangeEnd)
+	gar.Description = doc.Description
+	gar.Comment = doc.Comment
+	gar.Unfiltered = doc.Unfiltered
 	gar.Addresses = make([]GroupAddress, len(doc.GroupAddress))
 	gar.SubRanges = make([]GroupRange, len(doc.GroupRange))
 
 	for n, docGrpAddr := range doc.GroupAddress {
+		// Group addresses are secured automatically unless stated otherwise.
+		security := GroupAddressSecurityAuto
+		if docGrpAddr.Security != "" {
+			security = GroupAddressSecurity(docGrpAddr.Security)
+		}
+
 		gar.Addresses[n] = GroupAddress{
-			ID:      GroupAddressID(docGrpAddr.ID),
-			Name:    docGrpAddr.Name,
-			Address: docGrpAddr.Address,
+			ID:            GroupAddressID(docGrpAddr.ID),
+			Name:          docGrpAddr.Name,
+			Address:       GroupAddr(docGrpAddr.Address),
+			Description:   docGrpAddr.Description,
+			Comment:       docGrpAddr.Comment,
+			DatapointType: docGrpAddr.DatapointType,
+			Central:       docGrpAddr.Central,
+			Unfiltered:    docGrpAddr.Unfiltered,
+			Security:      security,
+			Puid:          docGrpAddr.Puid,
+			Key:           docGrpAddr.Key,
 		}
 	}
 
@@ -172,6 +382,183 @@ func (gar *groupRange11) UnmarshalXML(d *xml.Decoder, start xml.StartElement) er
 	return nil
 }
 
+// locationElements lists the elements that describe locations. Older schemas use BuildingPart
+// elements, newer schemas use Space elements. The kind of location is given by the 'Type'
+// attribute.
+var locationElements = map[string]bool{
+	"BuildingPart": true,
+	"Space":        true,
+}
+
+// viewItem11 is an element within a location or a trade.
+type viewItem11 struct {
+	location *Location
+	trade    *Trade
+	function *Function
+	deviceID DeviceInstanceID
+}
+
+func (vi *viewItem11) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	switch {
+	case locationElements[start.Name.Local]:
+		loc := &location11{}
+		if err := d.DecodeElement(loc, &start); err != nil {
+			return err
+		}
+
+		vi.location = (*Location)(loc)
+		return nil
+
+	case start.Name.Local == "Trade":
+		trade := &trade11{}
+		if err := d.DecodeElement(trade, &start); err != nil {
+			return err
+		}
+
+		vi.trade = (*Trade)(trade)
+		return nil
+
+	case start.Name.Local == "Function":
+		function := &function11{}
+		if err := d.DecodeElement(function, &start); err != nil {
+			return err
+		}
+
+		vi.function = (*Function)(function)
+		return nil
+
+	case start.Name.Local == "DeviceInstanceRef":
+		var doc struct {
+			RefID string `xml:"RefId,attr"`
+		}
+
+		if err := d.DecodeElement(&doc, &start); err != nil {
+			return err
+		}
+
+		vi.deviceID = DeviceInstanceID(doc.RefID)
+		return nil
+
+	default:
+		return d.Skip()
+	}
+}
+
+type function11 Function
+
+func (f *function11) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var doc struct {
+		ID               string `xml:"Id,attr"`
+		Name             string `xml:",attr"`
+		Type             string `xml:",attr"`
+		Number           string `xml:",attr"`
+		Description      string `xml:",attr"`
+		Comment          string `xml:",attr"`
+		GroupAddressRefs []struct {
+			ID    string `xml:"Id,attr"`
+			Name  string `xml:",attr"`
+			RefID string `xml:"RefId,attr"`
+			Role  string `xml:",attr"`
+		} `xml:"GroupAddressRef"`
+	}
+
+	if err := d.DecodeElement(&doc, &start); err != nil {
+		return err
+	}
+
+	f.ID = FunctionID(doc.ID)
+	f.Name = doc.Name
+	f.Type = FunctionType(doc.Type)
+	f.Number = doc.Number
+	f.Description = doc.Description
+	f.Comment = doc.Comment
+	f.GroupAddressRefs = make([]GroupAddressRef, len(doc.GroupAddressRefs))
+
+	for n, docRef := range doc.GroupAddressRefs {
+		f.GroupAddressRefs[n] = GroupAddressRef{
+			ID:    GroupAddressRefID(docRef.ID),
+			Name:  docRef.Name,
+			RefID: GroupAddressID(docRef.RefID),
+			Role:  docRef.Role,
+		}
+	}
+
+	return nil
+}
+
+type trade11 Trade
+
+func (t *trade11) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var doc struct {
+		ID          string       `xml:"Id,attr"`
+		Name        string       `xml:",attr"`
+		Number      string       `xml:",attr"`
+		Description string       `xml:",attr"`
+		Comment     string       `xml:",attr"`
+		Items       []viewItem11 `xml:",any"`
+	}
+
+	if err := d.DecodeElement(&doc, &start); err != nil {
+		return err
+	}
+
+	t.ID = TradeID(doc.ID)
+	t.Name = doc.Name
+	t.Number = doc.Number
+	t.Description = doc.Description
+	t.Comment = doc.Comment
+
+	for _, item := range doc.Items {
+		if item.trade != nil {
+			t.SubTrades = append(t.SubTrades, *item.trade)
+		} else if item.deviceID != "" {
+			t.Devices = append(t.Devices, item.deviceID)
+		}
+	}
+
+	return nil
+}
+
+type location11 Location
+
+func (l *location11) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var doc struct {
+		ID          string       `xml:"Id,attr"`
+		Name        string       `xml:",attr"`
+		Type        string       `xml:",attr"`
+		Number      string       `xml:",attr"`
+		Description string       `xml:",attr"`
+		Comment     string       `xml:",attr"`
+		Items       []viewItem11 `xml:",any"`
+	}
+
+	if err := d.DecodeElement(&doc, &start); err != nil {
+		return err
+	}
+
+	l.ID = LocationID(doc.ID)
+	l.Name = doc.Name
+	l.Type = LocationType(doc.Type)
+	l.Number = doc.Number
+	l.Description = doc.Description
+	l.Comment = doc.Comment
+
+	for _, item := range doc.Items {
+		switch {
+		case item.location != nil:
+			l.SubLocations = append(l.SubLocations, *item.location)
+
+		case item.function != nil:
+			l.Functions = append(l.Functions, *item.function)
+
+		case item.deviceID != "":
+			l.Devices = append(l.Devices, item.deviceID)
+		}
+	}
+
+	return nil
+}
+
 type installation11 Installation
 
 func (i *installation11) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
@@ -179,6 +566,8 @@ func (i *installation11) UnmarshalXML(d *xml.Decoder, start xml.StartElement) er
 		Name        string         `xml:",attr"`
 		Areas       []area11       `xml:"Topology>Area"`
 		GroupRanges []groupRange11 `xml:"GroupAddresses>GroupRanges>GroupRange"`
+		Locations   []location11   `xml:"Buildings>BuildingPart"`
+		Trades      []trade11      `xml:"Trades>Trade"`
 	}
 
 	if err := d.DecodeElement(&doc, &start); err != nil {
@@ -197,6 +586,18 @@ func (i *installation11) UnmarshalXML(d *xml.Decoder, start xml.StartElement) er
 		i.GroupAddresses[n] = GroupRange(docGrpRange)
 	}
 
+	i.Locations = make([]Location, len(doc.Locations))
+
+	for n, docLocation := range doc.Locations {
+		i.Locations[n] = Location(docLocation)
+	}
+
+	i.Trades = make([]Trade, len(doc.Trades))
+
+	for n, docTrade := range doc.Trades {
+		i.Trades[n] = Trade(docTrade)
+	}
+
 	return nil
 }
 
@@ -301,39 +702,279 @@ func (cor *comObjectRef11) UnmarshalXML(d *xml.Decoder, start xml.StartElement)
 	cor.DatapointType = doc.DatapointType
 	cor.Priority = doc.Priority
 
-	if doc.ReadFlag != nil {
-		cor.ReadFlag = new(bool)
-		*cor.ReadFlag = *doc.ReadFlag == "Enabled"
+	cor.ReadFlag = parseFlag(doc.ReadFlag)
+	cor.WriteFlag = parseFlag(doc.WriteFlag)
+	cor.CommunicationFlag = parseFlag(doc.CommunicationFlag)
+	cor.TransmitFlag = parseFlag(doc.TransmitFlag)
+	cor.UpdateFlag = parseFlag(doc.UpdateFlag)
+	cor.ReadOnInitFlag = parseFlag(doc.ReadOnInitFlag)
+
+	return nil
+}
+
+// parameterTypeRange are the attributes that limit a parameter type.
+type parameterTypeRange struct {
+	Minimum *float64 `xml:"minInclusive,attr"`
+	Maximum *float64 `xml:"maxInclusive,attr"`
+}
+
+type parameterType11 ParameterType
+
+func (pt *parameterType11) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var doc struct {
+		ID         string `xml:"Id,attr"`
+		Name       string `xml:",attr"`
+		TypeNumber *struct {
+			parameterTypeRange
+			SizeInBit uint   `xml:",attr"`
+			Type      string `xml:",attr"`
+		}
+		TypeRestriction *struct {
+			SizeInBit   uint `xml:",attr"`
+			Enumeration []struct {
+				ID    string `xml:"Id,attr"`
+				Text  string `xml:",attr"`
+				Value string `xml:",attr"`
+			}
+		}
+		TypeText *struct {
+			SizeInBit uint `xml:",attr"`
+		}
+		TypeFloat *struct {
+			parameterTypeRange
+			Encoding string `xml:",attr"`
+		}
+		TypeIPAddress *struct {
+			AddressType string `xml:",attr"`
+		}
+		TypeTime *struct {
+			parameterTypeRange
+			SizeInBit uint   `xml:",attr"`
+			Unit      string `xml:",attr"`
+		}
+		TypeDate *struct {
+			Encoding string `xml:",attr"`
+		}
+		TypeColor *struct {
+			Space string `xml:",attr"`
+		}
+		TypePicture *struct{}
+		TypeRawData *struct {
+			MaxSize uint `xml:",attr"`
+		}
 	}
 
-	if doc.WriteFlag != nil {
-		cor.WriteFlag = new(bool)
-		*cor.WriteFlag = *doc.WriteFlag == "Enabled"
+	if err := d.DecodeElement(&doc, &start); err != nil {
+		return err
 	}
 
-	if doc.CommunicationFlag != nil {
-		cor.CommunicationFlag = new(bool)
-		*cor.CommunicationFlag = *doc.CommunicationFlag == "Enabled"
+	pt.ID = ParameterTypeID(doc.ID)
+	pt.Name = doc.Name
+	pt.Kind = ParameterTypeNone
+
+	switch {
+	case doc.TypeNumber != nil:
+		pt.Kind = ParameterTypeNumber
+		pt.SizeInBit = doc.TypeNumber.SizeInBit
+		pt.NumberType = doc.TypeNumber.Type
+		pt.Minimum = doc.TypeNumber.Minimum
+		pt.Maximum = doc.TypeNumber.Maximum
+
+	case doc.TypeRestriction != nil:
+		pt.Kind = ParameterTypeEnumeration
+		pt.SizeInBit = doc.TypeRestriction.SizeInBit
+		pt.Enumeration = make([]ParameterEnumeration, len(doc.TypeRestriction.Enumeration))
+
+		for n, docEnum := range doc.TypeRestriction.Enumeration {
+			pt.Enumeration[n] = ParameterEnumeration{
+				ID:    docEnum.ID,
+				Text:  docEnum.Text,
+				Value: docEnum.Value,
+			}
+		}
+
+	case doc.TypeText != nil:
+		pt.Kind = ParameterTypeText
+		pt.SizeInBit = doc.TypeText.SizeInBit
+
+	case doc.TypeFloat != nil:
+		pt.Kind = ParameterTypeFloat
+		pt.Encoding = doc.TypeFloat.Encoding
+		pt.Minimum = doc.TypeFloat.Minimum
+		pt.Maximum = doc.TypeFloat.Maximum
+
+	case doc.TypeIPAddress != nil:
+		pt.Kind = ParameterTypeIPAddress
+		pt.SizeInBit = 32
+		pt.AddressType = doc.TypeIPAddress.AddressType
+
+	case doc.TypeTime != nil:
+		pt.Kind = ParameterTypeTime
+		pt.SizeInBit = doc.TypeTime.SizeInBit
+		pt.Unit = doc.TypeTime.Unit
+		pt.Minimum = doc.TypeTime.Minimum
+		pt.Maximum = doc.TypeTime.Maximum
+
+	case doc.TypeDate != nil:
+		pt.Kind = ParameterTypeDate
+		pt.Encoding = doc.TypeDate.Encoding
+
+	case doc.TypeColor != nil:
+		pt.Kind = ParameterTypeColor
+		pt.Encoding = doc.TypeColor.Space
+
+	case doc.TypePicture != nil:
+		pt.Kind = ParameterTypePicture
+
+	case doc.TypeRawData != nil:
+		pt.Kind = ParameterTypeRawData
+		pt.SizeInBit = doc.TypeRawData.MaxSize * 8
 	}
 
-	if doc.TransmitFlag != nil {
-		cor.TransmitFlag = new(bool)
-		*cor.TransmitFlag = *doc.TransmitFlag == "Enabled"
+	return nil
+}
+
+type parameter11 Parameter
+
+func (p *parameter11) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var doc struct {
+		ID                    string `xml:"Id,attr"`
+		Name                  string `xml:",attr"`
+		Text                  string `xml:",attr"`
+		ParameterType         string `xml:",attr"`
+		Access                string `xml:",attr"`
+		Value                 string `xml:",attr"`
+		DefaultUnionParameter bool   `xml:",attr"`
 	}
 
-	if doc.UpdateFlag != nil {
-		cor.UpdateFlag = new(bool)
-		*cor.UpdateFlag = *doc.UpdateFlag == "Enabled"
+	if err := d.DecodeElement(&doc, &start); err != nil {
+		return err
 	}
 
-	if doc.ReadOnInitFlag != nil {
-		cor.ReadOnInitFlag = new(bool)
-		*cor.ReadOnInitFlag = *doc.ReadOnInitFlag == "Enabled"
+	p.ID = ParameterID(doc.ID)
+	p.Name = doc.Name
+	p.Text = doc.Text
+	p.ParameterType = ParameterTypeID(doc.ParameterType)
+	p.Access = doc.Access
+	p.Value = doc.Value
+	p.DefaultUnionParameter = doc.DefaultUnionParameter
+
+	return nil
+}
+
+type parameterRef11 ParameterRef
+
+func (pr *parameterRef11) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var doc struct {
+		ID     string  `xml:"Id,attr"`
+		RefID  string  `xml:"RefId,attr"`
+		Name   *string `xml:",attr"`
+		Text   *string `xml:",attr"`
+		Access *string `xml:",attr"`
+		Value  *string `xml:",attr"`
 	}
 
+	if err := d.DecodeElement(&doc, &start); err != nil {
+		return err
+	}
+
+	pr.ID = ParameterRefID(doc.ID)
+	pr.RefID = ParameterID(doc.RefID)
+	pr.Name = doc.Name
+	pr.Text = doc.Text
+	pr.Access = doc.Access
+	pr.Value = doc.Value
+
 	return nil
 }
 
+// dynamicKinds maps the elements of the Dynamic section onto the kinds of items.
+var dynamicKinds = map[string]DynamicKind{
+	"Channel":                 DynamicChannel,
+	"ChannelIndependentBlock": DynamicChannelIndependentBlock,
+	"ParameterBlock":          DynamicParameterBlock,
+	"ParameterRefRef":         DynamicParameterRefRef,
+	"ComObjectRefRef":         DynamicComObjectRefRef,
+	"choose":                  DynamicChoose,
+	"when":                    DynamicWhen,
+	"Assign":                  DynamicAssign,
+	"Rename":                  DynamicRename,
+	"Module":                  DynamicModule,
+	"Repeat":                  DynamicRepeat,
+}
+
+// dynamicItem11 is an element within the Dynamic section. Elements that are not relevant to the
+// evaluation of the Dynamic section are marked as unknown.
+type dynamicItem11 struct {
+	DynamicItem
+	known bool
+}
+
+func (di *dynamicItem11) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var doc struct {
+		ID         string          `xml:"Id,attr"`
+		Name       string          `xml:",attr"`
+		Text       string          `xml:",attr"`
+		RefID      string          `xml:"RefId,attr"`
+		ParamRefID string          `xml:"ParamRefId,attr"`
+		Test       string          `xml:"test,attr"`
+		Default    bool            `xml:"default,attr"`
+		Target     string          `xml:"TargetParamRefRef,attr"`
+		Source     string          `xml:"SourceParamRefRef,attr"`
+		Value      string          `xml:",attr"`
+		Items      []dynamicItem11 `xml:",any"`
+	}
+
+	kind, known := dynamicKinds[start.Name.Local]
+	if !known {
+		return d.Skip()
+	}
+
+	if err := d.DecodeElement(&doc, &start); err != nil {
+		return err
+	}
+
+	di.known = true
+	di.Kind = kind
+	di.ID = doc.ID
+	di.Name = doc.Name
+	di.Text = doc.Text
+	di.Test = doc.Test
+	di.Default = doc.Default
+
+	switch kind {
+	case DynamicParameterRefRef:
+		di.ParameterRefID = ParameterRefID(doc.RefID)
+
+	case DynamicComObjectRefRef:
+		di.ComObjectRefID = ComObjectRefID(doc.RefID)
+
+	case DynamicChoose:
+		di.ParameterRefID = ParameterRefID(doc.ParamRefID)
+
+	case DynamicAssign:
+		di.ParameterRefID = ParameterRefID(doc.Target)
+		di.SourceParameterRefID = ParameterRefID(doc.Source)
+		di.Value = doc.Value
+	}
+
+	di.Items = convertDynamicItems11(doc.Items)
+
+	return nil
+}
+
+func convertDynamicItems11(docItems []dynamicItem11) []DynamicItem {
+	items := make([]DynamicItem, 0, len(docItems))
+
+	for _, docItem := range docItems {
+		if docItem.known {
+			items = append(items, docItem.DynamicItem)
+		}
+	}
+
+	return items
+}
+
 type applicationProgram11 ApplicationProgram
 
 func (ap *applicationProgram11) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
@@ -342,8 +983,18 @@ func (ap *applicationProgram11) UnmarshalXML(d *xml.Decoder, start xml.StartElem
 		Name    string `xml:",attr"`
 		Version uint   `xml:"ApplicationVersion,attr"`
 		Static  struct {
-			Objects    []comObject11    `xml:"ComObjectTable>ComObject"`
-			ObjectRefs []comObjectRef11 `xml:"ComObjectRefs>ComObjectRef"`
+			Objects        []comObject11     `xml:"ComObjectTable>ComObject"`
+			ObjectRefs     []comObjectRef11  `xml:"ComObjectRefs>ComObjectRef"`
+			ParameterTypes []parameterType11 `xml:"ParameterTypes>ParameterType"`
+			Parameters     []parameter11     `xml:"Parameters>Parameter"`
+			Unions         []struct {
+				SizeInBit  uint          `xml:",attr"`
+				Parameters []parameter11 `xml:"Parameter"`
+			} `xml:"Parameters>Union"`
+			ParameterRefs []parameterRef11 `xml:"ParameterRefs>ParameterRef"`
+		}
+		Dynamic struct {
+			Items []dynamicItem11 `xml:",any"`
 		}
 	}
 
@@ -365,6 +1016,155 @@ func (ap *applicationProgram11) UnmarshalXML(d *xml.Decoder, start xml.StartElem
 		ap.ObjectRefs[n] = ComObjectRef(docComObjRef)
 	}
 
+	ap.ParameterTypes = make([]ParameterType, len(doc.Static.ParameterTypes))
+	ap.Parameters = make([]Parameter, 0, len(doc.Static.Parameters))
+	ap.Unions = make([]ParameterUnion, len(doc.Static.Unions))
+	ap.ParameterRefs = make([]ParameterRef, len(doc.Static.ParameterRefs))
+
+	for n, docParamType := range doc.Static.ParameterTypes {
+		ap.ParameterTypes[n] = ParameterType(docParamType)
+	}
+
+	for _, docParam := range doc.Static.Parameters {
+		ap.Parameters = append(ap.Parameters, Parameter(docParam))
+	}
+
+	// Parameters within unions are listed among the other parameters.
+	for n, docUnion := range doc.Static.Unions {
+		union := ParameterUnion{
+			SizeInBit:  docUnion.SizeInBit,
+			Parameters: make([]ParameterID, len(docUnion.Parameters)),
+		}
+
+		for m, docParam := range docUnion.Parameters {
+			ap.Parameters = append(ap.Parameters, Parameter(docParam))
+			union.Parameters[m] = ParameterID(docParam.ID)
+		}
+
+		ap.Unions[n] = union
+	}
+
+	for n, docParamRef := range doc.Static.ParameterRefs {
+		ap.ParameterRefs[n] = ParameterRef(docParamRef)
+	}
+
+	ap.Dynamic = convertDynamicItems11(doc.Dynamic.Items)
+
+	return nil
+}
+
+type hardware11 Hardware
+
+func (hw *hardware11) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var doc struct {
+		ID                    string  `xml:"Id,attr"`
+		Name                  string  `xml:",attr"`
+		SerialNumber          string  `xml:",attr"`
+		VersionNumber         uint    `xml:",attr"`
+		BusCurrent            float64 `xml:",attr"`
+		HasIndividualAddress  bool    `xml:",attr"`
+		HasApplicationProgram bool    `xml:",attr"`
+		Products              []struct {
+			ID            string `xml:"Id,attr"`
+			Text          string `xml:",attr"`
+			OrderNumber   string `xml:",attr"`
+			IsRailMounted bool   `xml:",attr"`
+		} `xml:"Products>Product"`
+		Hardware2Programs []struct {
+			ID                     string `xml:"Id,attr"`
+			MediumTypes            string `xml:",attr"`
+			ApplicationProgramRefs []struct {
+				RefID string `xml:"RefId,attr"`
+			} `xml:"ApplicationProgramRef"`
+		} `xml:"Hardware2Programs>Hardware2Program"`
+	}
+
+	if err := d.DecodeElement(&doc, &start); err != nil {
+		return err
+	}
+
+	hw.ID = HardwareID(doc.ID)
+	hw.Name = doc.Name
+	hw.SerialNumber = doc.SerialNumber
+	hw.VersionNumber = doc.VersionNumber
+	hw.BusCurrent = doc.BusCurrent
+	hw.HasIndividualAddress = doc.HasIndividualAddress
+	hw.HasApplicationProgram = doc.HasApplicationProgram
+	hw.Products = make([]Product, len(doc.Products))
+	hw.Hardware2Programs = make([]Hardware2Program, len(doc.Hardware2Programs))
+
+	for n, docProduct := range doc.Products {
+		hw.Products[n] = Product{
+			ID:            ProductID(docProduct.ID),
+			Text:          docProduct.Text,
+			OrderNumber:   docProduct.OrderNumber,
+			IsRailMounted: docProduct.IsRailMounted,
+		}
+	}
+
+	for n, docH2P := range doc.Hardware2Programs {
+		h2p := Hardware2Program{
+			ID:                     Hardware2ProgramID(docH2P.ID),
+			ApplicationProgramRefs: make([]ApplicationProgramID, len(docH2P.ApplicationProgramRefs)),
+		}
+
+		for _, mediumType := range strings.Fields(docH2P.MediumTypes) {
+			h2p.MediumTypes = append(h2p.MediumTypes, MediumTypeID(mediumType))
+		}
+
+		for m, docProgRef := range docH2P.ApplicationProgramRefs {
+			h2p.ApplicationProgramRefs[m] = ApplicationProgramID(docProgRef.RefID)
+		}
+
+		hw.Hardware2Programs[n] = h2p
+	}
+
+	return nil
+}
+
+type catalogSection11 CatalogSection
+
+func (cs *catalogSection11) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var doc struct {
+		ID          string `xml:"Id,attr"`
+		Name        string `xml:",attr"`
+		Number      string `xml:",attr"`
+		CatalogItem []struct {
+			ID                    string `xml:"Id,attr"`
+			Name                  string `xml:",attr"`
+			Number                string `xml:",attr"`
+			VisibleDescription    string `xml:",attr"`
+			ProductRefID          string `xml:"ProductRefId,attr"`
+			Hardware2ProgramRefID string `xml:"Hardware2ProgramRefId,attr"`
+		}
+		CatalogSection []catalogSection11
+	}
+
+	if err := d.DecodeElement(&doc, &start); err != nil {
+		return err
+	}
+
+	cs.ID = CatalogSectionID(doc.ID)
+	cs.Name = doc.Name
+	cs.Number = doc.Number
+	cs.Items = make([]CatalogItem, len(doc.CatalogItem))
+	cs.Sections = make([]CatalogSection, len(doc.CatalogSection))
+
+	for n, docItem := range doc.CatalogItem {
+		cs.Items[n] = CatalogItem{
+			ID:                    CatalogItemID(docItem.ID),
+			Name:                  docItem.Name,
+			Number:                docItem.Number,
+			VisibleDescription:    docItem.VisibleDescription,
+			ProductRefID:          ProductID(docItem.ProductRefID),
+			Hardware2ProgramRefID: Hardware2ProgramID(docItem.Hardware2ProgramRefID),
+		}
+	}
+
+	for n, docSection := range doc.CatalogSection {
+		cs.Sections[n] = CatalogSection(docSection)
+	}
+
 	return nil
 }
 
@@ -375,6 +1175,8 @@ func (md *manufacturerData11) UnmarshalXML(d *xml.Decoder, start xml.StartElemen
 		Manufacturer struct {
 			ID       string                 `xml:"RefId,attr"`
 			Programs []applicationProgram11 `xml:"ApplicationPrograms>ApplicationProgram"`
+			Hardware []hardware11           `xml:"Hardware>Hardware"`
+			Catalog  []catalogSection11     `xml:"Catalog>CatalogSection"`
 		} `xml:"ManufacturerData>Manufacturer"`
 	}
 
@@ -384,10 +1186,155 @@ func (md *manufacturerData11) UnmarshalXML(d *xml.Decoder, start xml.StartElemen
 
 	md.Manufacturer = ManufacturerID(doc.Manufacturer.ID)
 	md.Programs = make([]ApplicationProgram, len(doc.Manufacturer.Programs))
+	md.Hardware = make([]Hardware, len(doc.Manufacturer.Hardware))
+	md.Catalog = make([]CatalogSection, len(doc.Manufacturer.Catalog))
 
 	for n, docProg := range doc.Manufacturer.Programs {
 		md.Programs[n] = ApplicationProgram(docProg)
 	}
 
+	for n, docHardware := range doc.Manufacturer.Hardware {
+		md.Hardware[n] = Hardware(docHardware)
+	}
+
+	for n, docSection := range doc.Manufacturer.Catalog {
+		md.Catalog[n] = CatalogSection(docSection)
+	}
+
+	return nil
+}
+
+type datapointFormat11 DatapointFormat
+
+func (df *datapointFormat11) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var doc struct {
+		ID    string `xml:"Id,attr"`
+		Name  string `xml:",attr"`
+		Width uint   `xml:",attr"`
+		Unit  string `xml:",attr"`
+	}
+
+	if err := d.DecodeElement(&doc, &start); err != nil {
+		return err
+	}
+
+	df.Kind = start.Name.Local
+	df.ID = doc.ID
+	df.Name = doc.Name
+	df.Width = doc.Width
+	df.Unit = doc.Unit
+
+	if df.Kind == "Bit" && df.Width == 0 {
+		df.Width = 1
+	}
+
+	return nil
+}
+
+type datapointType11 DatapointTypeDefinition
+
+func (dt *datapointType11) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var doc struct {
+		ID        string `xml:"Id,attr"`
+		Number    uint   `xml:",attr"`
+		Name      string `xml:",attr"`
+		Text      string `xml:",attr"`
+		SizeInBit uint   `xml:",attr"`
+		Subtypes  []struct {
+			ID      string `xml:"Id,attr"`
+			Number  uint   `xml:",attr"`
+			Name    string `xml:",attr"`
+			Text    string `xml:",attr"`
+			Default bool   `xml:",attr"`
+			Format  struct {
+				Elements []datapointFormat11 `xml:",any"`
+			}
+		} `xml:"DatapointSubtypes>DatapointSubtype"`
+	}
+
+	if err := d.DecodeElement(&doc, &start); err != nil {
+		return err
+	}
+
+	dt.ID = doc.ID
+	dt.Type = NewMainDatapointType(doc.Number)
+	dt.Name = doc.Name
+	dt.Text = doc.Text
+	dt.SizeInBit = doc.SizeInBit
+	dt.Subtypes = make([]DatapointSubtypeDefinition, len(doc.Subtypes))
+
+	for n, docSubtype := range doc.Subtypes {
+		sub := DatapointSubtypeDefinition{
+			ID:      docSubtype.ID,
+			Type:    NewDatapointType(doc.Number, docSubtype.Number),
+			Name:    docSubtype.Name,
+			Text:    docSubtype.Text,
+			Default: docSubtype.Default,
+			Format:  make([]DatapointFormat, len(docSubtype.Format.Elements)),
+		}
+
+		for m, docFormat := range docSubtype.Format.Elements {
+			sub.Format[m] = DatapointFormat(docFormat)
+		}
+
+		dt.Subtypes[n] = sub
+	}
+
+	return nil
+}
+
+type masterData11 MasterData
+
+func (md *masterData11) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var doc struct {
+		MasterData struct {
+			Version        string            `xml:",attr"`
+			DatapointTypes []datapointType11 `xml:"DatapointTypes>DatapointType"`
+			MediumTypes    []struct {
+				ID     string `xml:"Id,attr"`
+				Number uint   `xml:",attr"`
+				Name   string `xml:",attr"`
+				Text   string `xml:",attr"`
+			} `xml:"MediumTypes>MediumType"`
+			Manufacturers []struct {
+				ID                string `xml:"Id,attr"`
+				Name              string `xml:",attr"`
+				KnxManufacturerID uint   `xml:"KnxManufacturerId,attr"`
+				DefaultLanguage   string `xml:",attr"`
+			} `xml:"Manufacturers>Manufacturer"`
+		}
+	}
+
+	if err := d.DecodeElement(&doc, &start); err != nil {
+		return err
+	}
+
+	md.Version = doc.MasterData.Version
+	md.DatapointTypes = make([]DatapointTypeDefinition, len(doc.MasterData.DatapointTypes))
+	md.MediumTypes = make([]MediumType, len(doc.MasterData.MediumTypes))
+	md.Manufacturers = make([]Manufacturer, len(doc.MasterData.Manufacturers))
+
+	for n, docDatapointType := range doc.MasterData.DatapointTypes {
+		md.DatapointTypes[n] = DatapointTypeDefinition(docDatapointType)
+	}
+
+	for n, docMediumType := range doc.MasterData.MediumTypes {
+		md.MediumTypes[n] = MediumType{
+			ID:     MediumTypeID(docMediumType.ID),
+			Number: docMediumType.Number,
+			Name:   docMediumType.Name,
+			Text:   docMediumType.Text,
+		}
+	}
+
+	for n, docManu := range doc.MasterData.Manufacturers {
+		md.Manufacturers[n] = Manufacturer{
+			ID:                ManufacturerID(docManu.ID),
+			Name:              docManu.Name,
+			KnxManufacturerID: docManu.KnxManufacturerID,
+			DefaultLanguage:   docManu.DefaultLanguage,
+		}
+	}
+
 	return nil
 }
@@ -0,0 +1,6 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package ets
+
+const schema20Namespace = "http://knx.org/xml/project/20"
@@ -0,0 +1,288 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package ets
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+// schema21Namespace belongs to ETS6 projects. Projects of schema 20 share its layout and are decoded
+// using the types in this file as well.
+const schema21Namespace = "http://knx.org/xml/project/21"
+
+// completeID turns an ID that is relative to the installation (e.g. "GA-1") into a complete ID
+// using the prefix of a sibling ID (e.g. "P-0123-0_DI-1").
+func completeID(siblingID, id string) string {
+	if strings.Contains(id, "_") {
+		return id
+	}
+
+	prefix := siblingID
+	if index := strings.LastIndex(siblingID, "_"); index >= 0 {
+		prefix = siblingID[:index]
+	}
+
+	return prefix + "_" + id
+}
+
+type comObjectInstanceRef21 ComObjectInstanceRef
+
+func (cir *comObjectInstanceRef21) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	if err := d.DecodeElement((*comObjectInstanceRef11)(cir), &start); err != nil {
+		return err
+	}
+
+	// ETS6 lists the connected group addresses in the 'Links' attribute. The first group address
+	// is the one the communication object sends to.
+	for _, attr := range start.Attr {
+		if attr.Name.Local != "Links" {
+			continue
+		}
+
+		for n, link := range strings.Fields(attr.Value) {
+			cir.Connectors = append(cir.Connectors, Connector{
+				Receive: n > 0,
+				RefID:   GroupAddressID(link),
+			})
+		}
+	}
+
+	return nil
+}
+
+type deviceInstance21 DeviceInstance
+
+func (di *deviceInstance21) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var doc struct {
+		deviceInstanceAttrs
+		ComObjects []comObjectInstanceRef21 `xml:"ComObjectInstanceRefs>ComObjectInstanceRef"`
+	}
+
+	if err := d.DecodeElement(&doc, &start); err != nil {
+		return err
+	}
+
+	if err := doc.apply((*DeviceInstance)(di)); err != nil {
+		return err
+	}
+
+	di.ComObjects = make([]ComObjectInstanceRef, len(doc.ComObjects))
+
+	for n, docComObj := range doc.ComObjects {
+		comObj := ComObjectInstanceRef(docComObj)
+
+		// Group address references may be relative to the installation.
+		for m, conn := range comObj.Connectors {
+			comObj.Connectors[m].RefID = GroupAddressID(completeID(doc.ID, string(conn.RefID)))
+		}
+
+		di.ComObjects[n] = comObj
+	}
+
+	return nil
+}
+
+type line21 Line
+
+func (l *line21) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var doc struct {
+		lineAttrs
+		DeviceInstance []deviceInstance21
+		Segment        []struct {
+			ID              string `xml:"Id,attr"`
+			Name            string `xml:",attr"`
+			Number          uint   `xml:",attr"`
+			MediumTypeRefID string `xml:"MediumTypeRefId,attr"`
+			DomainAddress   string `xml:",attr"`
+			DeviceInstance  []deviceInstance21
+		}
+	}
+
+	if err := d.DecodeElement(&doc, &start); err != nil {
+		return err
+	}
+
+	doc.apply((*Line)(l))
+	l.Devices = make([]DeviceInstance, 0, len(doc.DeviceInstance))
+
+	for _, docDeviceInstance := range doc.DeviceInstance {
+		l.Devices = append(l.Devices, DeviceInstance(docDeviceInstance))
+	}
+
+	// ETS6 places the devices of a line in one or more segments.
+	for _, docSegment := range doc.Segment {
+		segment := Segment{
+			ID:            SegmentID(docSegment.ID),
+			Name:          docSegment.Name,
+			Number:        docSegment.Number,
+			MediumType:    MediumTypeID(docSegment.MediumTypeRefID),
+			DomainAddress: docSegment.DomainAddress,
+		}
+
+		for _, docDeviceInstance := range docSegment.DeviceInstance {
+			l.Devices = append(l.Devices, DeviceInstance(docDeviceInstance))
+			segment.Devices = append(segment.Devices, DeviceInstanceID(docDeviceInstance.ID))
+		}
+
+		l.Segments = append(l.Segments, segment)
+	}
+
+	// The medium of the line is given by its first segment.
+	if l.MediumType == "" && len(l.Segments) > 0 {
+		l.MediumType = l.Segments[0].MediumType
+		l.DomainAddress = l.Segments[0].DomainAddress
+	}
+
+	return nil
+}
+
+type area21 Area
+
+func (a *area21) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var doc struct {
+		ID      string `xml:"Id,attr"`
+		Name    string `xml:",attr"`
+		Address uint   `xml:",attr"`
+		Line    []line21
+	}
+
+	if err := d.DecodeElement(&doc, &start); err != nil {
+		return err
+	}
+
+	a.ID = AreaID(doc.ID)
+	a.Name = doc.Name
+	a.Address = AreaAddr(doc.Address)
+	a.Lines = make([]Line, len(doc.Line))
+
+	for n, docLine := range doc.Line {
+		a.Lines[n] = Line(docLine)
+	}
+
+	return nil
+}
+
+type location21 Location
+
+func (l *location21) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	if err := d.DecodeElement((*location11)(l), &start); err != nil {
+		return err
+	}
+
+	completeLocationIDs((*Location)(l))
+	return nil
+}
+
+// completeLocationIDs completes the device references and functions of the location and its
+// sub-locations.
+func completeLocationIDs(l *Location) {
+	for n, device := range l.Devices {
+		l.Devices[n] = DeviceInstanceID(completeID(string(l.ID), string(device)))
+	}
+
+	for n := range l.Functions {
+		function := &l.Functions[n]
+		function.ID = FunctionID(completeID(string(l.ID), string(function.ID)))
+
+		for m, ref := range function.GroupAddressRefs {
+			function.GroupAddressRefs[m].ID = GroupAddressRefID(completeID(string(l.ID), string(ref.ID)))
+			function.GroupAddressRefs[m].RefID = GroupAddressID(completeID(string(l.ID), string(ref.RefID)))
+		}
+	}
+
+	for n := range l.SubLocations {
+		completeLocationIDs(&l.SubLocations[n])
+	}
+}
+
+type trade21 Trade
+
+func (t *trade21) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	if err := d.DecodeElement((*trade11)(t), &start); err != nil {
+		return err
+	}
+
+	completeTradeIDs((*Trade)(t))
+	return nil
+}
+
+// completeTradeIDs completes the device references of the trade and its sub-trades.
+func completeTradeIDs(t *Trade) {
+	for n, device := range t.Devices {
+		t.Devices[n] = DeviceInstanceID(completeID(string(t.ID), string(device)))
+	}
+
+	for n := range t.SubTrades {
+		completeTradeIDs(&t.SubTrades[n])
+	}
+}
+
+type installation21 Installation
+
+func (i *installation21) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var doc struct {
+		Name        string         `xml:",attr"`
+		Areas       []area21       `xml:"Topology>Area"`
+		GroupRanges []groupRange11 `xml:"GroupAddresses>GroupRanges>GroupRange"`
+		Locations   []location21   `xml:"Locations>Space"`
+		Buildings   []location21   `xml:"Buildings>BuildingPart"`
+		Trades      []trade21      `xml:"Trades>Trade"`
+	}
+
+	if err := d.DecodeElement(&doc, &start); err != nil {
+		return err
+	}
+
+	i.Name = doc.Name
+	i.Topology = make([]Area, len(doc.Areas))
+	i.GroupAddresses = make([]GroupRange, len(doc.GroupRanges))
+
+	for n, docArea := range doc.Areas {
+		i.Topology[n] = Area(docArea)
+	}
+
+	for n, docGrpRange := range doc.GroupRanges {
+		i.GroupAddresses[n] = GroupRange(docGrpRange)
+	}
+
+	// ETS 5.7 and later call buildings locations.
+	i.Locations = make([]Location, 0, len(doc.Locations)+len(doc.Buildings))
+
+	for _, docLocation := range append(doc.Locations, doc.Buildings...) {
+		i.Locations = append(i.Locations, Location(docLocation))
+	}
+
+	i.Trades = make([]Trade, len(doc.Trades))
+
+	for n, docTrade := range doc.Trades {
+		i.Trades[n] = Trade(docTrade)
+	}
+
+	return nil
+}
+
+type project21 Project
+
+func (p *project21) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var doc struct {
+		Project struct {
+			ID            string           `xml:"Id,attr"`
+			Installations []installation21 `xml:"Installations>Installation"`
+		}
+	}
+
+	if err := d.DecodeElement(&doc, &start); err != nil {
+		return err
+	}
+
+	p.ID = ProjectID(doc.Project.ID)
+	p.Installations = make([]Installation, len(doc.Project.Installations))
+
+	for i, docInst := range doc.Project.Installations {
+		p.Installations[i] = Installation(docInst)
+	}
+
+	return nil
+}
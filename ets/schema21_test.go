@@ -0,0 +1,277 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package ets
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCompleteID(t *testing.T) {
+	tests := []struct {
+		siblingID string
+		id        string
+		want      string
+	}{
+		{"P-0123-0_DI-1", "GA-1", "P-0123-0_GA-1"},
+		{"P-0123-0_DI-1", "P-0123-0_GA-1", "P-0123-0_GA-1"},
+		{"P-0123-0_BP-1", "F-1_GAR-1", "F-1_GAR-1"},
+		{"P-0123-0", "DI-1", "P-0123-0_DI-1"},
+	}
+
+	for _, test := range tests {
+		if got := completeID(test.siblingID, test.id); got != test.want {
+			t.Errorf("completeID(%q, %q) = %q, want %q", test.siblingID, test.id, got, test.want)
+		}
+	}
+}
+
+// decodeTestProject decodes the project file in testdata and returns its only installation.
+func decodeTestProject(t *testing.T, name string) *Installation {
+	file, err := os.Open("testdata/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer file.Close()
+
+	proj, err := DecodeProject(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(proj.Installations) != 1 {
+		t.Fatalf("Got %d installations, want 1", len(proj.Installations))
+	}
+
+	return &proj.Installations[0]
+}
+
+func TestDecodeProjectSchema21(t *testing.T) {
+	inst := decodeTestProject(t, "schema21.xml")
+
+	if len(inst.Topology) != 1 || len(inst.Topology[0].Lines) != 1 {
+		t.Fatalf("Unexpected topology %+v", inst.Topology)
+	}
+
+	line := &inst.Topology[0].Lines[0]
+
+	// The medium of the line is taken from its first segment.
+	if line.MediumType != MediumTP {
+		t.Errorf("Line has medium %q, want %q", line.MediumType, MediumTP)
+	}
+
+	wantSegments := []Segment{
+		{
+			ID:         "P-0123-0_S-1",
+			Name:       "TP segment",
+			Number:     0,
+			MediumType: MediumTP,
+			Devices:    []DeviceInstanceID{"P-0123-0_DI-1"},
+		},
+		{
+			ID:            "P-0123-0_S-2",
+			Name:          "RF segment",
+			Number:        1,
+			MediumType:    MediumRF,
+			DomainAddress: "00FA12345678",
+			Devices:       []DeviceInstanceID{"P-0123-0_DI-2"},
+		},
+	}
+
+	if !reflect.DeepEqual(line.Segments, wantSegments) {
+		t.Errorf("Got segments %+v, want %+v", line.Segments, wantSegments)
+	}
+
+	if len(line.Devices) != 2 {
+		t.Fatalf("Got %d devices, want 2", len(line.Devices))
+	}
+
+	device := &line.Devices[0]
+	if device.ID != "P-0123-0_DI-1" || device.Address != 1 || !device.HasAddress {
+		t.Errorf("Unexpected device %+v", device)
+	}
+
+	if len(device.ComObjects) != 2 {
+		t.Fatalf("Got %d communication objects, want 2", len(device.ComObjects))
+	}
+
+	// The first link is the sending group address. Relative links are completed.
+	wantConnectors := [][]Connector{
+		{
+			{Receive: false, RefID: "P-0123-0_GA-1"},
+			{Receive: true, RefID: "P-0123-0_GA-2"},
+		},
+		{
+			{Receive: false, RefID: "P-0123-0_GA-2"},
+		},
+	}
+
+	for n, comObj := range device.ComObjects {
+		if !reflect.DeepEqual(comObj.Connectors, wantConnectors[n]) {
+			t.Errorf("Communication object %s has connectors %+v, want %+v",
+				comObj.RefID, comObj.Connectors, wantConnectors[n])
+		}
+	}
+
+	if device.ComObjects[0].DatapointType != "DPST-1-1" {
+		t.Errorf("Got datapoint type %q, want %q", device.ComObjects[0].DatapointType, "DPST-1-1")
+	}
+
+	if len(inst.GroupAddresses) != 1 || len(inst.GroupAddresses[0].Addresses) != 2 {
+		t.Fatalf("Unexpected group addresses %+v", inst.GroupAddresses)
+	}
+
+	if addr := inst.GroupAddresses[0].Addresses[0]; addr.ID != "P-0123-0_GA-1" || addr.Address != 2049 {
+		t.Errorf("Unexpected group address %+v", addr)
+	}
+
+	wantLocations := []Location{
+		{
+			ID:   "P-0123-0_BP-1",
+			Name: "House",
+			Type: LocationBuilding,
+			SubLocations: []Location{
+				{
+					ID:      "P-0123-0_BP-2",
+					Name:    "Kitchen",
+					Type:    LocationRoom,
+					Number:  "1.01",
+					Devices: []DeviceInstanceID{"P-0123-0_DI-1"},
+					Functions: []Function{
+						{
+							ID:   "P-0123-0_F-1",
+							Name: "Ceiling light",
+							Type: "FT-1",
+							GroupAddressRefs: []GroupAddressRef{
+								{
+									ID:    "F-1_GAR-1",
+									Name:  "On/Off",
+									RefID: "P-0123-0_GA-1",
+									Role:  "SwitchOnOff",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(inst.Locations, wantLocations) {
+		t.Errorf("Got locations %+v, want %+v", inst.Locations, wantLocations)
+	}
+
+	wantTrades := []Trade{
+		{
+			ID:      "P-0123-0_T-1",
+			Name:    "Lighting",
+			Devices: []DeviceInstanceID{"P-0123-0_DI-1"},
+			SubTrades: []Trade{
+				{
+					ID:      "P-0123-0_T-2",
+					Name:    "Switches",
+					Devices: []DeviceInstanceID{"P-0123-0_DI-2"},
+				},
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(inst.Trades, wantTrades) {
+		t.Errorf("Got trades %+v, want %+v", inst.Trades, wantTrades)
+	}
+}
+
+func TestDecodeProjectSchema20(t *testing.T) {
+	inst := decodeTestProject(t, "schema20.xml")
+
+	if len(inst.Topology) != 1 || len(inst.Topology[0].Lines) != 1 {
+		t.Fatalf("Unexpected topology %+v", inst.Topology)
+	}
+
+	line := &inst.Topology[0].Lines[0]
+	if line.MediumType != MediumTP || len(line.Segments) != 0 {
+		t.Errorf("Unexpected line %+v", line)
+	}
+
+	if len(line.Devices) != 1 || len(line.Devices[0].ComObjects) != 2 {
+		t.Fatalf("Unexpected devices %+v", line.Devices)
+	}
+
+	// Links and Connectors elements describe the same connections.
+	wantConnectors := [][]Connector{
+		{
+			{Receive: false, RefID: "P-0456-0_GA-5"},
+			{Receive: true, RefID: "P-0456-0_GA-6"},
+		},
+		{
+			{Receive: false, RefID: "P-0456-0_GA-6"},
+			{Receive: true, RefID: "P-0456-0_GA-5"},
+		},
+	}
+
+	for n, comObj := range line.Devices[0].ComObjects {
+		if !reflect.DeepEqual(comObj.Connectors, wantConnectors[n]) {
+			t.Errorf("Communication object %s has connectors %+v, want %+v",
+				comObj.RefID, comObj.Connectors, wantConnectors[n])
+		}
+	}
+
+	// Older exports call locations buildings.
+	if len(inst.Locations) != 1 {
+		t.Fatalf("Got %d locations, want 1", len(inst.Locations))
+	}
+
+	wantDevices := []DeviceInstanceID{"P-0456-0_DI-7"}
+
+	if !reflect.DeepEqual(inst.Locations[0].Devices, wantDevices) {
+		t.Errorf("Location contains %v, want %v", inst.Locations[0].Devices, wantDevices)
+	}
+
+	if len(inst.Trades) != 1 || !reflect.DeepEqual(inst.Trades[0].Devices, wantDevices) {
+		t.Errorf("Unexpected trades %+v", inst.Trades)
+	}
+}
+
+func TestDecodeProjectNamespaces(t *testing.T) {
+	tests := []struct {
+		namespace string
+		valid     bool
+	}{
+		{schema11Namespace, true},
+		{schema12Namespace, true},
+		{schema13Namespace, true},
+		{schema14Namespace, true},
+		{schema20Namespace, true},
+		{schema21Namespace, true},
+		{"http://knx.org/xml/project/99", false},
+	}
+
+	for _, test := range tests {
+		doc := `<KNX xmlns="` + test.namespace + `"><Project Id="P-0001"><Installations>` +
+			`<Installation Name="Test"><Topology><Area Id="P-0001-0_A-1" Address="1" /></Topology>` +
+			`</Installation></Installations></Project></KNX>`
+
+		proj, err := DecodeProject(strings.NewReader(doc))
+		if !test.valid {
+			if err == nil {
+				t.Errorf("Decoding namespace %s succeeded", test.namespace)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Decoding namespace %s failed: %v", test.namespace, err)
+			continue
+		}
+
+		if proj.ID != "P-0001" || len(proj.Installations) != 1 ||
+			len(proj.Installations[0].Topology) != 1 {
+			t.Errorf("Namespace %s: unexpected project %+v", test.namespace, proj)
+		}
+	}
+}
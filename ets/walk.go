@@ -0,0 +1,113 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package ets
+
+// WalkDevices calls fn for each device within the topology of the installation.
+func (inst *Installation) WalkDevices(fn func(area *Area, line *Line, device *DeviceInstance)) {
+	for a := range inst.Topology {
+		area := &inst.Topology[a]
+
+		for l := range area.Lines {
+			line := &area.Lines[l]
+
+			for d := range line.Devices {
+				fn(area, line, &line.Devices[d])
+			}
+		}
+	}
+}
+
+// WalkGroupAddresses calls fn for each group address within the installation. The ranges that
+// contain the group address are given from the outermost to the innermost range.
+func (inst *Installation) WalkGroupAddresses(fn func(ranges []*GroupRange, addr *GroupAddress)) {
+	for n := range inst.GroupAddresses {
+		walkGroupRange([]*GroupRange{&inst.GroupAddresses[n]}, fn)
+	}
+}
+
+func walkGroupRange(ranges []*GroupRange, fn func(ranges []*GroupRange, addr *GroupAddress)) {
+	gr := ranges[len(ranges)-1]
+
+	for n := range gr.Addresses {
+		fn(ranges, &gr.Addresses[n])
+	}
+
+	for n := range gr.SubRanges {
+		walkGroupRange(append(ranges[:len(ranges):len(ranges)], &gr.SubRanges[n]), fn)
+	}
+}
+
+// WalkLocations calls fn for each location within the installation. The locations that contain
+// the location are given first, the location itself is the last element.
+func (inst *Installation) WalkLocations(fn func(locations []*Location)) {
+	for n := range inst.Locations {
+		walkLocation([]*Location{&inst.Locations[n]}, fn)
+	}
+}
+
+func walkLocation(locations []*Location, fn func(locations []*Location)) {
+	fn(locations)
+
+	loc := locations[len(locations)-1]
+	for n := range loc.SubLocations {
+		walkLocation(append(locations[:len(locations):len(locations)], &loc.SubLocations[n]), fn)
+	}
+}
+
+// DeviceLocations maps each device that has been assigned to a location onto that location and the
+// locations containing it, from the outermost to the innermost location.
+func (inst *Installation) DeviceLocations() map[DeviceInstanceID][]*Location {
+	result := map[DeviceInstanceID][]*Location{}
+
+	inst.WalkLocations(func(locations []*Location) {
+		for _, device := range locations[len(locations)-1].Devices {
+			result[device] = locations
+		}
+	})
+
+	return result
+}
+
+// WalkFunctions calls fn for each function within the locations of the installation. The locations
+// that contain the function are given from the outermost to the innermost location.
+func (inst *Installation) WalkFunctions(fn func(locations []*Location, function *Function)) {
+	inst.WalkLocations(func(locations []*Location) {
+		loc := locations[len(locations)-1]
+
+		for n := range loc.Functions {
+			fn(locations, &loc.Functions[n])
+		}
+	})
+}
+
+// WalkTrades calls fn for each trade within the installation. The trades that contain the trade
+// are given first, the trade itself is the last element.
+func (inst *Installation) WalkTrades(fn func(trades []*Trade)) {
+	for n := range inst.Trades {
+		walkTrade([]*Trade{&inst.Trades[n]}, fn)
+	}
+}
+
+func walkTrade(trades []*Trade, fn func(trades []*Trade)) {
+	fn(trades)
+
+	trade := trades[len(trades)-1]
+	for n := range trade.SubTrades {
+		walkTrade(append(trades[:len(trades):len(trades)], &trade.SubTrades[n]), fn)
+	}
+}
+
+// DeviceTrades maps each device that has been assigned to a trade onto that trade and the trades
+// containing it, from the outermost to the innermost trade.
+func (inst *Installation) DeviceTrades() map[DeviceInstanceID][]*Trade {
+	result := map[DeviceInstanceID][]*Trade{}
+
+	inst.WalkTrades(func(trades []*Trade) {
+		for _, device := range trades[len(trades)-1].Devices {
+			result[device] = trades
+		}
+	})
+
+	return result
+}
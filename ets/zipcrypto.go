@@ -0,0 +1,232 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package ets
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/flate"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"unicode/utf16"
+
+	"github.com/vapourismo/ets-go/internal/pbkdf2"
+)
+
+var (
+	// ErrPassword is returned when the password for an encrypted file is wrong.
+	ErrPassword = errors.New("Invalid password")
+
+	// ErrPasswordRequired is returned when opening an encrypted file without a password.
+	ErrPasswordRequired = errors.New("Password required to open encrypted file")
+
+	// ErrAuthentication is returned when the contents of an encrypted file fail the integrity
+	// check.
+	ErrAuthentication = errors.New("Authentication of encrypted file failed")
+)
+
+const (
+	// winZipAESMethod is the compression method used to mark WinZip AES encrypted entries.
+	winZipAESMethod = 99
+
+	// winZipAESExtraID is the ID of the extra field that holds the WinZip AES parameters.
+	winZipAESExtraID = 0x9901
+
+	winZipAESVerifierLen   = 2
+	winZipAESAuthCodeLen   = 10
+	winZipAESKeyIterations = 1000
+)
+
+// projectPassword turns the password entered in ETS into the password that protects the entries
+// of a project archive. Starting with schema version 21 (ETS6), ETS no longer uses the password as
+// is but derives it from the given password.
+func projectPassword(password string, schemaVersion int) []byte {
+	if schemaVersion < 21 {
+		return []byte(password)
+	}
+
+	codes := utf16.Encode([]rune(password))
+	encoded := make([]byte, 2*len(codes))
+	for n, code := range codes {
+		binary.LittleEndian.PutUint16(encoded[2*n:], code)
+	}
+
+	key := pbkdf2.Key(sha256.New, encoded, []byte("21.project.ets.knx.org"), 65536, 32)
+	return []byte(base64.StdEncoding.EncodeToString(key))
+}
+
+// winZipAESParams are the parameters of a WinZip AES encrypted entry.
+type winZipAESParams struct {
+	version uint16
+	keyLen  int
+	method  uint16
+}
+
+func getWinZipAESParams(file *zip.File) (params winZipAESParams, err error) {
+	extra := file.Extra
+
+	for len(extra) >= 4 {
+		id := binary.LittleEndian.Uint16(extra[0:])
+		size := int(binary.LittleEndian.Uint16(extra[2:]))
+		extra = extra[4:]
+
+		if size > len(extra) {
+			break
+		}
+
+		if id == winZipAESExtraID && size >= 7 {
+			params.version = binary.LittleEndian.Uint16(extra[0:])
+			params.method = binary.LittleEndian.Uint16(extra[5:])
+
+			switch extra[4] {
+			case 1:
+				params.keyLen = 16
+			case 2:
+				params.keyLen = 24
+			case 3:
+				params.keyLen = 32
+			default:
+				return params, fmt.Errorf("Unknown AES strength %d in '%s'", extra[4], file.Name)
+			}
+
+			return params, nil
+		}
+
+		extra = extra[size:]
+	}
+
+	return params, fmt.Errorf("Missing AES parameters in '%s'", file.Name)
+}
+
+// winZipAESStream implements the counter mode used by WinZip AES. Unlike the counter mode found in
+// crypto/cipher, the counter is incremented as a little-endian number and starts at 1.
+type winZipAESStream struct {
+	block     cipher.Block
+	counter   [aes.BlockSize]byte
+	keyStream [aes.BlockSize]byte
+	used      int
+}
+
+func newWinZipAESStream(block cipher.Block) *winZipAESStream {
+	return &winZipAESStream{block: block, used: aes.BlockSize}
+}
+
+func (s *winZipAESStream) XORKeyStream(dst, src []byte) {
+	for n := range src {
+		if s.used == aes.BlockSize {
+			for m := range s.counter {
+				s.counter[m]++
+				if s.counter[m] != 0 {
+					break
+				}
+			}
+
+			s.block.Encrypt(s.keyStream[:], s.counter[:])
+			s.used = 0
+		}
+
+		dst[n] = src[n] ^ s.keyStream[s.used]
+		s.used++
+	}
+}
+
+// openEncryptedFile opens a WinZip AES encrypted file contained in the archive backed by r.
+func openEncryptedFile(r io.ReaderAt, file *zip.File, password []byte) (io.ReadCloser, error) {
+	params, err := getWinZipAESParams(file)
+	if err != nil {
+		return nil, err
+	}
+
+	offset, err := file.DataOffset()
+	if err != nil {
+		return nil, err
+	}
+
+	saltLen := params.keyLen / 2
+	overhead := saltLen + winZipAESVerifierLen + winZipAESAuthCodeLen
+
+	if file.CompressedSize64 < uint64(overhead) {
+		return nil, fmt.Errorf("Encrypted file '%s' is too short", file.Name)
+	}
+
+	data := make([]byte, file.CompressedSize64)
+	if _, err := r.ReadAt(data, offset); err != nil {
+		return nil, err
+	}
+
+	salt := data[:saltLen]
+	verifier := data[saltLen : saltLen+winZipAESVerifierLen]
+	content := data[saltLen+winZipAESVerifierLen : len(data)-winZipAESAuthCodeLen]
+	authCode := data[len(data)-winZipAESAuthCodeLen:]
+
+	keys := pbkdf2.Key(sha1.New, password, salt, winZipAESKeyIterations, 2*params.keyLen+winZipAESVerifierLen)
+	if !bytes.Equal(keys[2*params.keyLen:], verifier) {
+		return nil, ErrPassword
+	}
+
+	mac := hmac.New(sha1.New, keys[params.keyLen:2*params.keyLen])
+	mac.Write(content)
+	if !hmac.Equal(mac.Sum(nil)[:winZipAESAuthCodeLen], authCode) {
+		return nil, ErrAuthentication
+	}
+
+	block, err := aes.NewCipher(keys[:params.keyLen])
+	if err != nil {
+		return nil, err
+	}
+
+	newWinZipAESStream(block).XORKeyStream(content, content)
+
+	var rc io.ReadCloser
+	switch params.method {
+	case zip.Store:
+		rc = ioutil.NopCloser(bytes.NewReader(content))
+
+	case zip.Deflate:
+		rc = flate.NewReader(bytes.NewReader(content))
+
+	default:
+		return nil, zip.ErrAlgorithm
+	}
+
+	// Version 1 of the format still carries the checksum of the decrypted contents.
+	if params.version == 1 {
+		return &checksumReader{rc: rc, hash: crc32.NewIEEE(), want: file.CRC32}, nil
+	}
+
+	return rc, nil
+}
+
+// checksumReader verifies the CRC-32 checksum of the contents once they have been read entirely.
+type checksumReader struct {
+	rc   io.ReadCloser
+	hash hash.Hash32
+	want uint32
+}
+
+func (r *checksumReader) Read(b []byte) (n int, err error) {
+	n, err = r.rc.Read(b)
+	r.hash.Write(b[:n])
+
+	if err == io.EOF && r.hash.Sum32() != r.want {
+		err = zip.ErrChecksum
+	}
+
+	return
+}
+
+func (r *checksumReader) Close() error {
+	return r.rc.Close()
+}
@@ -0,0 +1,78 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package ets
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/ioutil"
+	"testing"
+)
+
+func TestProjectPassword(t *testing.T) {
+	tests := []struct {
+		password      string
+		schemaVersion int
+		want          string
+	}{
+		{"test", 20, "test"},
+		{"test", 21, "2+IIP7ErCPPKxFjJXc59GFx2+w/1VTLHjJ2duc04CYQ="},
+		{"Passwört", 21, "dsWqwUk+z0BOlVzjEEcpg20SbjFKICCPmO28dRWyR18="},
+	}
+
+	for _, test := range tests {
+		if got := string(projectPassword(test.password, test.schemaVersion)); got != test.want {
+			t.Errorf("projectPassword(%q, %d) = %q, want %q", test.password, test.schemaVersion, got, test.want)
+		}
+	}
+}
+
+// openTestEntry opens the only entry of the AES-128 encrypted archive in testdata. The entry has
+// been encrypted with the password "secret".
+func openTestEntry(t *testing.T, contents []byte, password string) ([]byte, error) {
+	r := bytes.NewReader(contents)
+
+	archive, err := zip.NewReader(r, int64(len(contents)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rc, err := openEncryptedFile(r, archive.File[0], []byte(password))
+	if err != nil {
+		return nil, err
+	}
+
+	defer rc.Close()
+
+	return ioutil.ReadAll(rc)
+}
+
+func TestOpenEncryptedFile(t *testing.T) {
+	contents, err := ioutil.ReadFile("testdata/aes-encrypted.zip")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := openTestEntry(t, contents, "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(data) != "<KNX>hello</KNX>\n" {
+		t.Errorf("Unexpected contents %q", data)
+	}
+
+	if _, err := openTestEntry(t, contents, "wrong"); err != ErrPassword {
+		t.Errorf("Expected ErrPassword, got %v", err)
+	}
+
+	// Flip a bit of the encrypted contents, which are preceded by the local file header, the
+	// 8-byte salt and the 2-byte password verifier.
+	tampered := append([]byte(nil), contents...)
+	tampered[30+len("0.xml")+11+8+2] ^= 1
+
+	if _, err := openTestEntry(t, tampered, "secret"); err != ErrAuthentication {
+		t.Errorf("Expected ErrAuthentication, got %v", err)
+	}
+}
@@ -0,0 +1,330 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package gaexport
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"github.com/vapourismo/ets-go/ets"
+)
+
+var csvHeader = []string{
+	"Main",
+	"Middle",
+	"Sub",
+	"Address",
+	"Central",
+	"Unfiltered",
+	"Description",
+	"DatapointType",
+	"Security",
+}
+
+const (
+	csvMain = iota
+	csvMiddle
+	csvSub
+	csvAddress
+	csvCentral
+	csvUnfiltered
+	csvDescription
+	csvDatapointType
+	csvSecurity
+)
+
+// formatFlag formats a flag the way ETS does. Flags that are not set are left empty.
+func formatFlag(flag bool) string {
+	if flag {
+		return "x"
+	}
+
+	return ""
+}
+
+// parseFlag parses a flag. Any value other than an empty string, "0" or "false" sets the flag.
+func parseFlag(s string) bool {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "0", "false":
+		return false
+
+	default:
+		return true
+	}
+}
+
+// formatSecurity formats the security mode of a group address. Group addresses without a security
+// mode are secured automatically.
+func formatSecurity(security ets.GroupAddressSecurity) string {
+	if security == "" {
+		return string(ets.GroupAddressSecurityAuto)
+	}
+
+	return string(security)
+}
+
+// formatRangeAddr formats the address of a group range at the given level, e.g. "1/2/-".
+func formatRangeAddr(gr *ets.GroupRange, level int, style ets.GroupAddrStyle) string {
+	switch {
+	case style == ets.GroupAddrStyleFree:
+		return ""
+
+	case style == ets.GroupAddrStyleTwoLevel:
+		return fmt.Sprintf("%d/-", gr.RangeStart.Main())
+
+	case level == 0:
+		return fmt.Sprintf("%d/-/-", gr.RangeStart.Main())
+
+	default:
+		return fmt.Sprintf("%d/%d/-", gr.RangeStart.Main(), gr.RangeStart.Middle())
+	}
+}
+
+// parseRangeAddr parses the address of a group range such as "1/2/-" or "1/-".
+func parseRangeAddr(s string) (start, end ets.GroupAddr, ok bool) {
+	parts := strings.Split(s, "/")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, 0, false
+	}
+
+	main, err := strconv.ParseUint(parts[0], 10, 5)
+	if err != nil {
+		return 0, 0, false
+	}
+
+	if len(parts) == 3 && parts[1] != "-" {
+		middle, err := strconv.ParseUint(parts[1], 10, 3)
+		if err != nil {
+			return 0, 0, false
+		}
+
+		return ets.NewGroupAddr3(uint8(main), uint8(middle), 0),
+			ets.NewGroupAddr3(uint8(main), uint8(middle), 255),
+			true
+	}
+
+	return ets.NewGroupAddr2(uint8(main), 0), ets.NewGroupAddr2(uint8(main), 2047), true
+}
+
+// readRangeAddr parses the address of a group range in the given line of a CSV file. Group ranges
+// that have been written in the free style have no address.
+func readRangeAddr(s string, line int) (start, end ets.GroupAddr, err error) {
+	if s == "" {
+		return 0, 0, nil
+	}
+
+	start, end, ok := parseRangeAddr(s)
+	if !ok {
+		return 0, 0, fmt.Errorf("Line %d: Invalid range address '%s'", line, s)
+	}
+
+	return start, end, nil
+}
+
+func writeCSVRange(cw *csv.Writer, gr *ets.GroupRange, level int, style ets.GroupAddrStyle) error {
+	row := make([]string, len(csvHeader))
+	if level == 0 {
+		row[csvMain] = gr.Name
+	} else {
+		row[csvMiddle] = gr.Name
+	}
+
+	row[csvAddress] = formatRangeAddr(gr, level, style)
+	row[csvUnfiltered] = formatFlag(gr.Unfiltered)
+	row[csvDescription] = gr.Description
+	row[csvSecurity] = string(ets.GroupAddressSecurityAuto)
+
+	if err := cw.Write(row); err != nil {
+		return err
+	}
+
+	for _, addr := range gr.Addresses {
+		row := make([]string, len(csvHeader))
+		row[csvSub] = addr.Name
+		row[csvAddress] = addr.Address.Format(style)
+		row[csvCentral] = formatFlag(addr.Central)
+		row[csvUnfiltered] = formatFlag(addr.Unfiltered)
+		row[csvDescription] = addr.Description
+		row[csvDatapointType] = addr.DatapointType
+		row[csvSecurity] = formatSecurity(addr.Security)
+
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	for n := range gr.SubRanges {
+		if err := writeCSVRange(cw, &gr.SubRanges[n], level+1, style); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WriteCSV writes the group ranges and their group addresses in the CSV format. Group ranges that
+// are nested deeper than two levels are written as middle groups.
+func WriteCSV(w io.Writer, ranges []ets.GroupRange, style ets.GroupAddrStyle) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for n := range ranges {
+		if err := writeCSVRange(cw, &ranges[n], 0, style); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// detectSeparator guesses the separator of the CSV file from its first line. ETS can be configured
+// to use commas, semicolons or tabs.
+func detectSeparator(contents []byte) rune {
+	line := contents
+	if index := bytes.IndexByte(contents, '\n'); index >= 0 {
+		line = contents[:index]
+	}
+
+	separator, count := ',', bytes.Count(line, []byte{','})
+
+	for _, candidate := range []rune{';', '\t'} {
+		if n := bytes.Count(line, []byte{byte(candidate)}); n > count {
+			separator, count = candidate, n
+		}
+	}
+
+	return separator
+}
+
+// ReadCSV reads group ranges and group addresses in the CSV format. The columns are identified by
+// the header.
+func ReadCSV(r io.Reader) ([]ets.GroupRange, error) {
+	contents, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	contents = bytes.TrimPrefix(contents, []byte("\xEF\xBB\xBF"))
+
+	cr := csv.NewReader(bytes.NewReader(contents))
+	cr.Comma = detectSeparator(contents)
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make([]int, len(csvHeader))
+	for n, name := range csvHeader {
+		columns[n] = -1
+
+		for m, field := range header {
+			if strings.EqualFold(strings.TrimSpace(field), name) {
+				columns[n] = m
+			}
+		}
+	}
+
+	for _, column := range []int{csvMain, csvMiddle, csvSub, csvAddress} {
+		if columns[column] < 0 {
+			return nil, fmt.Errorf("Missing column '%s'", csvHeader[column])
+		}
+	}
+
+	var ranges []ets.GroupRange
+
+	for line := 2; ; line++ {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		get := func(column int) string {
+			if columns[column] < 0 || columns[column] >= len(record) {
+				return ""
+			}
+
+			return record[columns[column]]
+		}
+
+		switch {
+		case get(csvMain) != "":
+			start, end, err := readRangeAddr(get(csvAddress), line)
+			if err != nil {
+				return nil, err
+			}
+
+			ranges = append(ranges, ets.GroupRange{
+				Name:        get(csvMain),
+				RangeStart:  start,
+				RangeEnd:    end,
+				Description: get(csvDescription),
+				Unfiltered:  parseFlag(get(csvUnfiltered)),
+			})
+
+		case get(csvMiddle) != "":
+			if len(ranges) == 0 {
+				return nil, fmt.Errorf("Line %d: Middle group outside of a main group", line)
+			}
+
+			start, end, err := readRangeAddr(get(csvAddress), line)
+			if err != nil {
+				return nil, err
+			}
+
+			main := &ranges[len(ranges)-1]
+			main.SubRanges = append(main.SubRanges, ets.GroupRange{
+				Name:        get(csvMiddle),
+				RangeStart:  start,
+				RangeEnd:    end,
+				Description: get(csvDescription),
+				Unfiltered:  parseFlag(get(csvUnfiltered)),
+			})
+
+		case get(csvSub) != "":
+			if len(ranges) == 0 {
+				return nil, fmt.Errorf("Line %d: Group address outside of a group range", line)
+			}
+
+			address, err := ets.ParseGroupAddr(get(csvAddress))
+			if err != nil {
+				return nil, fmt.Errorf("Line %d: %v", line, err)
+			}
+
+			parent := &ranges[len(ranges)-1]
+			if len(parent.SubRanges) > 0 {
+				parent = &parent.SubRanges[len(parent.SubRanges)-1]
+			}
+
+			security := ets.GroupAddressSecurity(get(csvSecurity))
+			if security == "" {
+				security = ets.GroupAddressSecurityAuto
+			}
+
+			parent.Addresses = append(parent.Addresses, ets.GroupAddress{
+				Name:          get(csvSub),
+				Address:       address,
+				Description:   get(csvDescription),
+				DatapointType: get(csvDatapointType),
+				Central:       parseFlag(get(csvCentral)),
+				Unfiltered:    parseFlag(get(csvUnfiltered)),
+				Security:      security,
+			})
+		}
+	}
+
+	return ranges, nil
+}
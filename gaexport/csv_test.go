@@ -0,0 +1,209 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package gaexport
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/vapourismo/ets-go/ets"
+)
+
+func TestReadCSVRangeAddress(t *testing.T) {
+	tests := []struct {
+		contents string
+		valid    bool
+	}{
+		{"Main,Middle,Sub,Address\nLights,,,1/-/-\n,Kitchen,,1/2/-\n,,Ceiling,1/2/3\n", true},
+		{"Main,Middle,Sub,Address\nLights,,,\n,,Ceiling,2563\n", true},
+		{"Main,Middle,Sub,Address\nLights,,,x/-/-\n", false},
+		{"Main,Middle,Sub,Address\nLights,,,1/-/-\n,Kitchen,,1/9/-\n", false},
+	}
+
+	for _, test := range tests {
+		_, err := ReadCSV(strings.NewReader(test.contents))
+		if valid := err == nil; valid != test.valid {
+			t.Errorf("ReadCSV(%q): unexpected error %v", test.contents, err)
+		}
+	}
+}
+
+// testRanges contains a main group with two middle groups and a main group that contains group
+// addresses directly. The range addresses are those that a CSV file in the three-level style
+// describes.
+var testRanges = []ets.GroupRange{
+	{
+		Name:        "Lights",
+		RangeStart:  ets.NewGroupAddr3(1, 0, 0),
+		RangeEnd:    ets.NewGroupAddr3(1, 7, 255),
+		Description: "All lights",
+		SubRanges: []ets.GroupRange{
+			{
+				Name:       "Kitchen",
+				RangeStart: ets.NewGroupAddr3(1, 2, 0),
+				RangeEnd:   ets.NewGroupAddr3(1, 2, 255),
+				Addresses: []ets.GroupAddress{
+					{
+						Name:          "Ceiling, on/off",
+						Address:       ets.NewGroupAddr3(1, 2, 3),
+						Description:   `The "big" one`,
+						DatapointType: "DPST-1-1",
+						Security:      ets.GroupAddressSecurityAuto,
+					},
+					{
+						Name:          "Ceiling brightness",
+						Address:       ets.NewGroupAddr3(1, 2, 4),
+						DatapointType: "DPST-5-1 DPT-5",
+						Security:      ets.GroupAddressSecurityOn,
+					},
+				},
+			},
+			{
+				Name:       "Living room",
+				RangeStart: ets.NewGroupAddr3(1, 3, 0),
+				RangeEnd:   ets.NewGroupAddr3(1, 3, 255),
+				Unfiltered: true,
+				Addresses: []ets.GroupAddress{
+					{
+						Name:          "Wall light",
+						Address:       ets.NewGroupAddr3(1, 3, 0),
+						DatapointType: "DPST-1-1",
+						Central:       true,
+						Unfiltered:    true,
+						Security:      ets.GroupAddressSecurityOff,
+					},
+				},
+			},
+		},
+	},
+	{
+		Name:       "Central",
+		RangeStart: ets.NewGroupAddr3(31, 0, 0),
+		RangeEnd:   ets.NewGroupAddr3(31, 7, 255),
+		Addresses: []ets.GroupAddress{
+			{
+				Name:        "Alarm",
+				Address:     ets.NewGroupAddr3(31, 7, 255),
+				Description: "Ünïcödé",
+				Security:    ets.GroupAddressSecurityAuto,
+			},
+		},
+	},
+}
+
+// describeRanges flattens group ranges into comparable strings.
+func describeRanges(ranges []ets.GroupRange) []string {
+	var result []string
+
+	var walk func(prefix string, ranges []ets.GroupRange)
+	walk = func(prefix string, ranges []ets.GroupRange) {
+		for _, gr := range ranges {
+			result = append(result, fmt.Sprintf(
+				"%srange %q %v-%v %q %v",
+				prefix, gr.Name, gr.RangeStart, gr.RangeEnd, gr.Description, gr.Unfiltered,
+			))
+
+			for _, addr := range gr.Addresses {
+				result = append(result, fmt.Sprintf(
+					"%s  address %q %v %q %q %v %v %s",
+					prefix, addr.Name, addr.Address, addr.Description, addr.DatapointType,
+					addr.Central, addr.Unfiltered, addr.Security,
+				))
+			}
+
+			walk(prefix+"  ", gr.SubRanges)
+		}
+	}
+
+	walk("", ranges)
+	return result
+}
+
+func TestCSVRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, testRanges, ets.GroupAddrStyleThreeLevel); err != nil {
+		t.Fatal(err)
+	}
+
+	ranges, err := ReadCSV(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := describeRanges(ranges), describeRanges(testRanges); !reflect.DeepEqual(got, want) {
+		t.Errorf("Got:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestCSVRoundTripStyles(t *testing.T) {
+	// Only main groups can be described in the two-level style, the free style describes no
+	// ranges at all.
+	flat := []ets.GroupRange{testRanges[1]}
+
+	for _, style := range []ets.GroupAddrStyle{ets.GroupAddrStyleTwoLevel, ets.GroupAddrStyleFree} {
+		var buf bytes.Buffer
+		if err := WriteCSV(&buf, flat, style); err != nil {
+			t.Fatal(err)
+		}
+
+		ranges, err := ReadCSV(&buf)
+		if err != nil {
+			t.Fatalf("%v: %v", style, err)
+		}
+
+		if len(ranges) != 1 || len(ranges[0].Addresses) != 1 {
+			t.Fatalf("%v: got %+v", style, ranges)
+		}
+
+		if addr := ranges[0].Addresses[0]; addr.Address != flat[0].Addresses[0].Address {
+			t.Errorf("%v: got address %v, want %v", style, addr.Address, flat[0].Addresses[0].Address)
+		}
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, testRanges[1:], ets.GroupAddrStyleThreeLevel); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "Main,Middle,Sub,Address,Central,Unfiltered,Description,DatapointType,Security\n" +
+		"Central,,,31/-/-,,,,,Auto\n" +
+		",,Alarm,31/7/255,,,Ünïcödé,,Auto\n"
+
+	if got := buf.String(); got != want {
+		t.Errorf("Got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestReadCSVSeparators(t *testing.T) {
+	tests := []string{
+		"Main,Middle,Sub,Address,DatapointType\nLights,,,1/-/-\n,,\"Switch, kitchen\",1/0/1,DPST-1-1\n",
+		"Main;Middle;Sub;Address;DatapointType\nLights;;;1/-/-\n;;Switch, kitchen;1/0/1;DPST-1-1\n",
+		"Main\tMiddle\tSub\tAddress\tDatapointType\nLights\t\t\t1/-/-\n\t\tSwitch, kitchen\t1/0/1\tDPST-1-1\n",
+		"\xEF\xBB\xBFMain;Middle;Sub;Address;DatapointType\r\nLights;;;1/-/-\r\n;;Switch, kitchen;1/0/1;DPST-1-1\r\n",
+	}
+
+	for _, contents := range tests {
+		ranges, err := ReadCSV(strings.NewReader(contents))
+		if err != nil {
+			t.Errorf("ReadCSV(%q): %v", contents, err)
+			continue
+		}
+
+		if len(ranges) != 1 || ranges[0].Name != "Lights" || len(ranges[0].Addresses) != 1 {
+			t.Errorf("ReadCSV(%q) = %+v", contents, ranges)
+			continue
+		}
+
+		addr := ranges[0].Addresses[0]
+		if addr.Name != "Switch, kitchen" || addr.Address != ets.NewGroupAddr3(1, 0, 1) ||
+			addr.DatapointType != "DPST-1-1" {
+			t.Errorf("ReadCSV(%q) yields group address %+v", contents, addr)
+		}
+	}
+}
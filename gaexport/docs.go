@@ -0,0 +1,16 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+/*
+Package gaexport reads and writes group addresses in the formats that ETS uses to import and export
+them.
+
+The CSV format is the one ETS calls "3/1" where each group range and group address occupies one row
+and the column of its name indicates its level. The XML format is the "GroupAddress-Export" format.
+
+	err := gaexport.WriteCSV(os.Stdout, inst.GroupAddresses, info.GroupAddressStyle)
+
+Group ranges and group addresses that have been read from one of these formats carry no IDs, because
+the formats do not include them.
+*/
+package gaexport
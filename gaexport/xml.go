@@ -0,0 +1,156 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package gaexport
+
+import (
+	"encoding/xml"
+	"io"
+
+	"github.com/vapourismo/ets-go/ets"
+)
+
+const xmlNamespace = "http://knx.org/xml/ga-export/01"
+
+type xmlGroupAddress struct {
+	Name        string `xml:",attr"`
+	Address     string `xml:",attr"`
+	Central     bool   `xml:",attr,omitempty"`
+	Unfiltered  bool   `xml:",attr,omitempty"`
+	Description string `xml:",attr,omitempty"`
+	DPTs        string `xml:",attr,omitempty"`
+	Security    string `xml:",attr,omitempty"`
+}
+
+type xmlGroupRange struct {
+	Name        string            `xml:",attr"`
+	RangeStart  uint              `xml:",attr"`
+	RangeEnd    uint              `xml:",attr"`
+	Unfiltered  bool              `xml:",attr,omitempty"`
+	Description string            `xml:",attr,omitempty"`
+	SubRanges   []xmlGroupRange   `xml:"GroupRange"`
+	Addresses   []xmlGroupAddress `xml:"GroupAddress"`
+}
+
+type xmlExport struct {
+	XMLName xml.Name        `xml:"GroupAddress-Export"`
+	Xmlns   string          `xml:"xmlns,attr"`
+	Ranges  []xmlGroupRange `xml:"GroupRange"`
+}
+
+func newXMLGroupRange(gr *ets.GroupRange, style ets.GroupAddrStyle) xmlGroupRange {
+	result := xmlGroupRange{
+		Name:        gr.Name,
+		RangeStart:  uint(gr.RangeStart),
+		RangeEnd:    uint(gr.RangeEnd),
+		Unfiltered:  gr.Unfiltered,
+		Description: gr.Description,
+		SubRanges:   make([]xmlGroupRange, len(gr.SubRanges)),
+		Addresses:   make([]xmlGroupAddress, len(gr.Addresses)),
+	}
+
+	for n := range gr.SubRanges {
+		result.SubRanges[n] = newXMLGroupRange(&gr.SubRanges[n], style)
+	}
+
+	for n, addr := range gr.Addresses {
+		result.Addresses[n] = xmlGroupAddress{
+			Name:        addr.Name,
+			Address:     addr.Address.Format(style),
+			Central:     addr.Central,
+			Unfiltered:  addr.Unfiltered,
+			Description: addr.Description,
+			DPTs:        addr.DatapointType,
+			Security:    formatSecurity(addr.Security),
+		}
+	}
+
+	return result
+}
+
+// WriteXML writes the group ranges and their group addresses in the XML format.
+func WriteXML(w io.Writer, ranges []ets.GroupRange, style ets.GroupAddrStyle) error {
+	doc := xmlExport{Xmlns: xmlNamespace, Ranges: make([]xmlGroupRange, len(ranges))}
+	for n := range ranges {
+		doc.Ranges[n] = newXMLGroupRange(&ranges[n], style)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func newGroupRange(doc *xmlGroupRange) (ets.GroupRange, error) {
+	gr := ets.GroupRange{
+		Name:        doc.Name,
+		RangeStart:  ets.GroupAddr(doc.RangeStart),
+		RangeEnd:    ets.GroupAddr(doc.RangeEnd),
+		Description: doc.Description,
+		Unfiltered:  doc.Unfiltered,
+		SubRanges:   make([]ets.GroupRange, len(doc.SubRanges)),
+		Addresses:   make([]ets.GroupAddress, len(doc.Addresses)),
+	}
+
+	for n := range doc.SubRanges {
+		subRange, err := newGroupRange(&doc.SubRanges[n])
+		if err != nil {
+			return gr, err
+		}
+
+		gr.SubRanges[n] = subRange
+	}
+
+	for n, docAddr := range doc.Addresses {
+		address, err := ets.ParseGroupAddr(docAddr.Address)
+		if err != nil {
+			return gr, err
+		}
+
+		security := ets.GroupAddressSecurity(docAddr.Security)
+		if security == "" {
+			security = ets.GroupAddressSecurityAuto
+		}
+
+		gr.Addresses[n] = ets.GroupAddress{
+			Name:          docAddr.Name,
+			Address:       address,
+			Description:   docAddr.Description,
+			DatapointType: docAddr.DPTs,
+			Central:       docAddr.Central,
+			Unfiltered:    docAddr.Unfiltered,
+			Security:      security,
+		}
+	}
+
+	return gr, nil
+}
+
+// ReadXML reads group ranges and group addresses in the XML format.
+func ReadXML(r io.Reader) ([]ets.GroupRange, error) {
+	var doc xmlExport
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	ranges := make([]ets.GroupRange, len(doc.Ranges))
+	for n := range doc.Ranges {
+		gr, err := newGroupRange(&doc.Ranges[n])
+		if err != nil {
+			return nil, err
+		}
+
+		ranges[n] = gr
+	}
+
+	return ranges, nil
+}
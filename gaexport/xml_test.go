@@ -0,0 +1,61 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package gaexport
+
+import (
+	"bytes"
+	"encoding/xml"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/vapourismo/ets-go/ets"
+)
+
+func TestXMLRoundTrip(t *testing.T) {
+	for _, style := range []ets.GroupAddrStyle{
+		ets.GroupAddrStyleThreeLevel, ets.GroupAddrStyleTwoLevel, ets.GroupAddrStyleFree,
+	} {
+		var buf bytes.Buffer
+		if err := WriteXML(&buf, testRanges, style); err != nil {
+			t.Fatal(err)
+		}
+
+		ranges, err := ReadXML(&buf)
+		if err != nil {
+			t.Fatalf("%v: %v", style, err)
+		}
+
+		if got, want := describeRanges(ranges), describeRanges(testRanges); !reflect.DeepEqual(got, want) {
+			t.Errorf("%v: got:\n%s\nwant:\n%s", style, strings.Join(got, "\n"), strings.Join(want, "\n"))
+		}
+	}
+}
+
+func TestWriteXMLNamespace(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteXML(&buf, testRanges, ets.GroupAddrStyleThreeLevel); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(buf.String(), xml.Header) {
+		t.Errorf("Output does not start with the XML header")
+	}
+
+	dec := xml.NewDecoder(&buf)
+	for {
+		token, err := dec.Token()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if start, ok := token.(xml.StartElement); ok {
+			if start.Name.Local != "GroupAddress-Export" || start.Name.Space != xmlNamespace {
+				t.Errorf("Root element is %v, want GroupAddress-Export in %s", start.Name, xmlNamespace)
+			}
+
+			return
+		}
+	}
+}
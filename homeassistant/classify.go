@@ -0,0 +1,174 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package homeassistant
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/vapourismo/ets-go/ets"
+)
+
+// role is the purpose of a group address within an entity.
+type role int
+
+const (
+	roleUnknown role = iota
+	roleSwitch
+	roleBinary
+	roleUpDown
+	roleStop
+	rolePercent
+	roleTemperature
+	roleSensor
+)
+
+// sensorTypes maps datapoint types to the value types of Home Assistant's KNX sensors.
+var sensorTypes = map[string]string{
+	"5.001":  "percent",
+	"5.004":  "percentU8",
+	"5.010":  "pulse",
+	"7.001":  "pulse_2byte",
+	"7.013":  "brightness",
+	"9.001":  "temperature",
+	"9.004":  "illuminance",
+	"9.005":  "wind_speed_ms",
+	"9.006":  "pressure_2byte",
+	"9.007":  "humidity",
+	"9.008":  "ppm",
+	"9.020":  "voltage",
+	"9.021":  "curr",
+	"9.024":  "power_2byte",
+	"12.001": "pulse_4_ucount",
+	"13.010": "active_energy",
+	"13.013": "active_energy_kwh",
+	"14.019": "electric_current",
+	"14.027": "electric_potential",
+	"14.056": "power",
+}
+
+// Words that indicate the purpose of a group address. They are removed from the name of the group
+// address in order to find group addresses that belong to the same entity.
+var (
+	statusWords = []string{"status", "state", "feedback", "rückmeldung", "rm", "zustand"}
+
+	setpointWords = []string{"setpoint", "sollwert", "soll", "target"}
+
+	functionWords = []string{
+		"switch", "schalten", "on", "off", "ein", "aus",
+		"brightness", "helligkeit", "dimmwert", "dimming", "dimmen", "value", "wert",
+		"position", "up", "down", "auf", "ab", "move", "fahren", "stop",
+		"temperature", "temperatur", "actual", "ist",
+	}
+
+	lightWords = []string{"light", "licht", "lamp", "lampe", "leuchte", "beleuchtung"}
+)
+
+// parseDatapointType parses the first datapoint type listed in s, e.g. "DPST-9-1".
+func parseDatapointType(s string) (ets.DatapointType, bool) {
+	dpts, err := ets.ParseDatapointTypes(s)
+	if err != nil || len(dpts) == 0 {
+		return ets.DatapointType{}, false
+	}
+
+	return dpts[0], true
+}
+
+// roleOf determines the purpose of a group address based on its datapoint type.
+func roleOf(dpt ets.DatapointType) role {
+	switch {
+	case dpt == ets.NewDatapointType(1, 1):
+		return roleSwitch
+
+	case dpt == ets.NewDatapointType(1, 8):
+		return roleUpDown
+
+	case dpt.HasSub && dpt.Main == 1 && (dpt.Sub == 7 || dpt.Sub == 10 || dpt.Sub == 17):
+		return roleStop
+
+	case dpt.Main == 1:
+		return roleBinary
+
+	case dpt == ets.NewDatapointType(5, 1):
+		return rolePercent
+
+	case dpt == ets.NewDatapointType(9, 1):
+		return roleTemperature
+	}
+
+	if _, ok := sensorTypes[dpt.String()]; ok {
+		return roleSensor
+	}
+
+	return roleUnknown
+}
+
+// candidate is a group address that may become part of an entity.
+type candidate struct {
+	addr     *ets.GroupAddress
+	dpt      ets.DatapointType
+	role     role
+	status   bool
+	setpoint bool
+	light    bool
+	baseName string
+}
+
+func splitWords(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func isWord(word string, list []string) bool {
+	for _, item := range list {
+		if strings.EqualFold(word, item) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsWord(words []string, list []string) bool {
+	for _, word := range words {
+		if isWord(word, list) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// baseName removes the words that describe the purpose of a group address from its name.
+func baseName(words []string) string {
+	var result []string
+
+	for _, word := range words {
+		if !isWord(word, statusWords) && !isWord(word, setpointWords) && !isWord(word, functionWords) {
+			result = append(result, word)
+		}
+	}
+
+	return strings.Join(result, " ")
+}
+
+func newCandidate(ranges []*ets.GroupRange, addr *ets.GroupAddress, dpt ets.DatapointType) *candidate {
+	words := splitWords(addr.Name)
+
+	var rangeWords []string
+	for _, gr := range ranges {
+		rangeWords = append(rangeWords, splitWords(gr.Name)...)
+	}
+
+	return &candidate{
+		addr:     addr,
+		dpt:      dpt,
+		role:     roleOf(dpt),
+		status:   containsWord(words, statusWords),
+		setpoint: containsWord(words, setpointWords),
+		light:    containsWord(words, lightWords) || containsWord(rangeWords, lightWords),
+		baseName: baseName(words),
+	}
+}
@@ -0,0 +1,293 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package homeassistant
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/vapourismo/ets-go/ets"
+)
+
+// Option is a configuration option of an entity.
+type Option struct {
+	Key   string
+	Value string
+}
+
+// Entity is a Home Assistant entity.
+type Entity struct {
+	// Platform is the Home Assistant platform, e.g. "light" or "sensor".
+	Platform string
+	Name     string
+	Options  []Option
+
+	// Addresses contains the group addresses that the entity has been generated from.
+	Addresses []*ets.GroupAddress
+}
+
+// Config is a generated configuration.
+type Config struct {
+	Entities []Entity
+
+	// Unclassified contains the group addresses that could not be turned into an entity.
+	Unclassified []*ets.GroupAddress
+}
+
+// group contains the candidates that belong to the same entity.
+type group struct {
+	name       string
+	candidates []*candidate
+	used       map[*candidate]bool
+}
+
+// take returns the first unused candidate that matches the given criteria and marks it as used.
+func (g *group) take(r role, status, setpoint bool) *candidate {
+	for _, c := range g.candidates {
+		if !g.used[c] && c.role == r && c.status == status && c.setpoint == setpoint {
+			g.used[c] = true
+			return c
+		}
+	}
+
+	return nil
+}
+
+// has determines whether an unused candidate matches the given criteria.
+func (g *group) has(r role, status, setpoint bool) bool {
+	for _, c := range g.candidates {
+		if !g.used[c] && c.role == r && c.status == status && c.setpoint == setpoint {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (g *group) isLight() bool {
+	for _, c := range g.candidates {
+		if c.light {
+			return true
+		}
+	}
+
+	return false
+}
+
+// generator turns groups into entities.
+type generator struct {
+	style  ets.GroupAddrStyle
+	config *Config
+}
+
+// entity starts a new entity. Options are added using the returned function which ignores
+// missing candidates. A candidate may be used for more than one option.
+func (gen *generator) entity(platform, name string) (*Entity, func(key string, c *candidate)) {
+	gen.config.Entities = append(gen.config.Entities, Entity{Platform: platform, Name: name})
+	entity := &gen.config.Entities[len(gen.config.Entities)-1]
+
+	return entity, func(key string, c *candidate) {
+		if c == nil {
+			return
+		}
+
+		entity.Options = append(entity.Options, Option{Key: key, Value: c.addr.Address.Format(gen.style)})
+
+		for _, addr := range entity.Addresses {
+			if addr == c.addr {
+				return
+			}
+		}
+
+		entity.Addresses = append(entity.Addresses, c.addr)
+	}
+}
+
+func (gen *generator) generateMain(g *group) {
+	switch {
+	case g.has(roleUpDown, false, false):
+		_, add := gen.entity("cover", g.name)
+		add("move_long_address", g.take(roleUpDown, false, false))
+		add("stop_address", g.take(roleStop, false, false))
+		add("position_address", g.take(rolePercent, false, false))
+		add("position_state_address", g.take(rolePercent, true, false))
+
+	case g.has(roleTemperature, false, true) &&
+		(g.has(roleTemperature, false, false) || g.has(roleTemperature, true, false)):
+		_, add := gen.entity("climate", g.name)
+
+		actual := g.take(roleTemperature, true, false)
+		if actual == nil {
+			actual = g.take(roleTemperature, false, false)
+		}
+
+		setpoint := g.take(roleTemperature, false, true)
+
+		// Home Assistant requires a state address for the setpoint. Devices without a separate
+		// status address report the setpoint on the address it is set with.
+		setpointState := g.take(roleTemperature, true, true)
+		if setpointState == nil {
+			setpointState = setpoint
+		}
+
+		add("temperature_address", actual)
+		add("target_temperature_address", setpoint)
+		add("target_temperature_state_address", setpointState)
+
+	case g.has(roleSwitch, false, false) && (g.has(rolePercent, false, false) || g.isLight()):
+		_, add := gen.entity("light", g.name)
+		add("address", g.take(roleSwitch, false, false))
+		add("state_address", g.take(roleSwitch, true, false))
+		add("brightness_address", g.take(rolePercent, false, false))
+		add("brightness_state_address", g.take(rolePercent, true, false))
+
+	case g.has(roleSwitch, false, false):
+		_, add := gen.entity("switch", g.name)
+		add("address", g.take(roleSwitch, false, false))
+		add("state_address", g.take(roleSwitch, true, false))
+	}
+}
+
+// generateRemaining turns the candidates that are not part of the main entity of the group into
+// sensors or reports them as unclassified.
+func (gen *generator) generateRemaining(g *group) {
+	for _, c := range g.candidates {
+		if g.used[c] {
+			continue
+		}
+
+		g.used[c] = true
+
+		switch c.role {
+		case roleSwitch, roleBinary:
+			_, add := gen.entity("binary_sensor", c.addr.Name)
+			add("state_address", c)
+
+		case rolePercent, roleTemperature, roleSensor:
+			entity, add := gen.entity("sensor", c.addr.Name)
+			add("state_address", c)
+			entity.Options = append(entity.Options, Option{Key: "type", Value: sensorTypes[c.dpt.String()]})
+
+		default:
+			gen.config.Unclassified = append(gen.config.Unclassified, c.addr)
+		}
+	}
+}
+
+// datapointTypeOf determines the datapoint type of a group address. If the group address has no
+// datapoint type, the first datapoint type of a connected communication object is used.
+func datapointTypeOf(
+	addr *ets.GroupAddress,
+	idx *ets.GroupAddressIndex,
+	resolver *ets.ComObjectResolver,
+) (ets.DatapointType, bool) {
+	if dpt, ok := parseDatapointType(addr.DatapointType); ok {
+		return dpt, true
+	}
+
+	for _, conn := range idx.Connections(addr.ID) {
+		comObj := resolver.Resolve(conn.Device, conn.ComObject)
+		if dpt, ok := parseDatapointType(comObj.DatapointType); ok {
+			return dpt, true
+		}
+	}
+
+	return ets.DatapointType{}, false
+}
+
+// Generate the configuration for the given project. Group addresses are formatted in the given
+// style.
+func Generate(proj *ets.Project, manufacturers []*ets.ManufacturerData, style ets.GroupAddrStyle) *Config {
+	idx := ets.NewGroupAddressIndex(proj)
+	resolver := ets.NewComObjectResolver(manufacturers)
+	gen := &generator{style: style, config: &Config{}}
+
+	var groups []*group
+	groupsByKey := map[string]*group{}
+
+	for i := range proj.Installations {
+		proj.Installations[i].WalkGroupAddresses(func(ranges []*ets.GroupRange, addr *ets.GroupAddress) {
+			dpt, ok := datapointTypeOf(addr, idx, resolver)
+			if !ok {
+				gen.config.Unclassified = append(gen.config.Unclassified, addr)
+				return
+			}
+
+			c := newCandidate(ranges, addr, dpt)
+
+			name := c.baseName
+			if name == "" {
+				name = ranges[len(ranges)-1].Name
+			}
+
+			key := string(ranges[len(ranges)-1].ID) + "/" + strings.ToLower(name)
+
+			g, ok := groupsByKey[key]
+			if !ok {
+				g = &group{name: name, used: map[*candidate]bool{}}
+				groupsByKey[key] = g
+				groups = append(groups, g)
+			}
+
+			g.candidates = append(g.candidates, c)
+		})
+	}
+
+	for _, g := range groups {
+		gen.generateMain(g)
+		gen.generateRemaining(g)
+	}
+
+	return gen.config
+}
+
+// platforms returns the platforms of all entities in alphabetical order.
+func (c *Config) platforms() []string {
+	seen := map[string]bool{}
+
+	var platforms []string
+	for _, entity := range c.Entities {
+		if !seen[entity.Platform] {
+			seen[entity.Platform] = true
+			platforms = append(platforms, entity.Platform)
+		}
+	}
+
+	sort.Strings(platforms)
+	return platforms
+}
+
+// WriteYAML writes the configuration in the format expected by Home Assistant's KNX integration.
+func (c *Config) WriteYAML(w io.Writer) error {
+	if _, err := fmt.Fprintln(w, "knx:"); err != nil {
+		return err
+	}
+
+	for _, platform := range c.platforms() {
+		if _, err := fmt.Fprintf(w, "  %s:\n", platform); err != nil {
+			return err
+		}
+
+		for _, entity := range c.Entities {
+			if entity.Platform != platform {
+				continue
+			}
+
+			if _, err := fmt.Fprintf(w, "    - name: %s\n", strconv.Quote(entity.Name)); err != nil {
+				return err
+			}
+
+			for _, option := range entity.Options {
+				if _, err := fmt.Fprintf(w, "      %s: %s\n", option.Key, strconv.Quote(option.Value)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
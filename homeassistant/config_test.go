@@ -0,0 +1,131 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package homeassistant
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/vapourismo/ets-go/ets"
+)
+
+// testAddr creates a group address with the given three-level address.
+func testAddr(id, name string, main, middle, sub uint8, dpt string) ets.GroupAddress {
+	return ets.GroupAddress{
+		ID:            ets.GroupAddressID(id),
+		Name:          name,
+		Address:       ets.NewGroupAddr3(main, middle, sub),
+		DatapointType: dpt,
+	}
+}
+
+// testProject contains a light, a cover, a climate entity, a sensor and two group addresses that
+// cannot be classified.
+var testProject = ets.Project{
+	Installations: []ets.Installation{{
+		GroupAddresses: []ets.GroupRange{
+			{
+				ID:   "GR-1",
+				Name: "Lights",
+				SubRanges: []ets.GroupRange{{
+					ID:   "GR-2",
+					Name: "Living room",
+					Addresses: []ets.GroupAddress{
+						testAddr("GA-1", "Living room light switch", 1, 0, 1, "DPST-1-1"),
+						testAddr("GA-2", "Living room light status", 1, 0, 2, "DPST-1-1"),
+						testAddr("GA-3", "Living room light brightness", 1, 0, 3, "DPST-5-1"),
+						testAddr("GA-4", "Living room light brightness status", 1, 0, 4, "DPST-5-1"),
+					},
+				}},
+			},
+			{
+				ID:   "GR-3",
+				Name: "Blinds",
+				SubRanges: []ets.GroupRange{{
+					ID:   "GR-4",
+					Name: "Kitchen",
+					Addresses: []ets.GroupAddress{
+						testAddr("GA-5", "Kitchen blind up/down", 2, 0, 1, "DPST-1-8"),
+						testAddr("GA-6", "Kitchen blind stop", 2, 0, 2, "DPST-1-7"),
+						testAddr("GA-7", "Kitchen blind position", 2, 0, 3, "DPST-5-1"),
+						testAddr("GA-8", "Kitchen blind position status", 2, 0, 4, "DPST-5-1"),
+					},
+				}},
+			},
+			{
+				ID:   "GR-5",
+				Name: "Heating",
+				SubRanges: []ets.GroupRange{{
+					ID:   "GR-6",
+					Name: "Bathroom",
+					Addresses: []ets.GroupAddress{
+						testAddr("GA-9", "Bathroom actual temperature", 3, 0, 1, "DPST-9-1"),
+						testAddr("GA-10", "Bathroom setpoint temperature", 3, 0, 2, "DPST-9-1"),
+						testAddr("GA-11", "Bathroom scene", 3, 0, 3, "DPST-17-1"),
+						testAddr("GA-12", "Outside temperature", 3, 0, 4, "DPST-9-1"),
+						testAddr("GA-13", "Spare", 3, 0, 5, ""),
+					},
+				}},
+			},
+		},
+	}},
+}
+
+func TestGenerate(t *testing.T) {
+	config := Generate(&testProject, nil, ets.GroupAddrStyleThreeLevel)
+
+	var buf bytes.Buffer
+	if err := config.WriteYAML(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	want, err := ioutil.ReadFile("testdata/config.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Errorf("Got configuration:\n%s\nwant:\n%s", buf.Bytes(), want)
+	}
+
+	var unclassified []string
+	for _, addr := range config.Unclassified {
+		unclassified = append(unclassified, addr.Name)
+	}
+
+	if len(unclassified) != 2 || unclassified[0] != "Spare" || unclassified[1] != "Bathroom scene" {
+		t.Errorf("Got unclassified group addresses %q", unclassified)
+	}
+}
+
+func TestGenerateClimateSetpointState(t *testing.T) {
+	config := Generate(&testProject, nil, ets.GroupAddrStyleThreeLevel)
+
+	for _, entity := range config.Entities {
+		if entity.Platform != "climate" {
+			continue
+		}
+
+		// Without a separate status address the setpoint reports its own state.
+		options := map[string]string{}
+		for _, option := range entity.Options {
+			options[option.Key] = option.Value
+		}
+
+		if options["target_temperature_state_address"] != "3/0/2" {
+			t.Errorf("Got target temperature state address %q, want %q",
+				options["target_temperature_state_address"], "3/0/2")
+		}
+
+		if len(entity.Addresses) != 2 {
+			t.Errorf("Climate entity has been generated from %d group addresses, want 2",
+				len(entity.Addresses))
+		}
+
+		return
+	}
+
+	t.Error("No climate entity has been generated")
+}
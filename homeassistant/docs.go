@@ -0,0 +1,21 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+/*
+Package homeassistant generates the KNX configuration of Home Assistant from an ETS project.
+
+Each group address is classified by its datapoint type. If the group address has no datapoint type,
+the datapoint types of the communication objects connected to it are used instead. Group addresses
+within the same group range whose names only differ by words such as "status", "brightness" or
+"position" are combined into one entity.
+
+	config := homeassistant.Generate(proj, manufacturers, info.GroupAddressStyle)
+	config.WriteYAML(os.Stdout)
+
+	for _, addr := range config.Unclassified {
+		log.Println("Could not classify", addr.Name)
+	}
+
+The output is deterministic. Entities appear in the order of their group addresses in the project.
+*/
+package homeassistant
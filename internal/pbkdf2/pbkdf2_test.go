@@ -0,0 +1,43 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package pbkdf2
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"testing"
+)
+
+// The test vectors for PBKDF2-HMAC-SHA1 have been taken from RFC 6070.
+var rfc6070Vectors = []struct {
+	password   string
+	salt       string
+	iterations int
+	key        string
+}{
+	{"password", "salt", 1, "0c60c80f961f0e71f3a9b524af6012062fe037a6"},
+	{"password", "salt", 2, "ea6c014dc72d6f8ccd1ed92ace1d41f0d8de8957"},
+	{"password", "salt", 4096, "4b007901b765489abead49d926f721d065a429c1"},
+	{
+		"passwordPASSWORDpassword",
+		"saltSALTsaltSALTsaltSALTsaltSALTsalt",
+		4096,
+		"3d2eec4fe41c849b80c8d83662c0e44a8b291a964cf2f07038",
+	},
+	{"pass\x00word", "sa\x00lt", 4096, "56fa6aa75548099dcc37d7f03425e0c3"},
+}
+
+func TestKey(t *testing.T) {
+	for _, vector := range rfc6070Vectors {
+		want, err := hex.DecodeString(vector.key)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		key := Key(sha1.New, []byte(vector.password), []byte(vector.salt), vector.iterations, len(want))
+		if got := hex.EncodeToString(key); got != vector.key {
+			t.Errorf("Key(%q, %q, %d) = %s, want %s", vector.password, vector.salt, vector.iterations, got, vector.key)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package keyring
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/vapourismo/ets-go/internal/pbkdf2"
+)
+
+// ErrSignature is returned when the signature of a keyring does not match its contents. This is
+// usually caused by a wrong password.
+var ErrSignature = errors.New("Invalid keyring signature")
+
+// cipherParams contains the key and initialization vector that protect the keys and passwords
+// within a keyring.
+type cipherParams struct {
+	key []byte
+	iv  []byte
+}
+
+// newCipherParams derives the parameters from the password and the creation timestamp of the
+// keyring.
+func newCipherParams(password, created string) *cipherParams {
+	createdHash := sha256.Sum256([]byte(created))
+
+	return &cipherParams{
+		key: pbkdf2.Key(sha256.New, []byte(password), []byte("1.keyring.ets.knx.org"), 65536, 16),
+		iv:  createdHash[:16],
+	}
+}
+
+// decrypt decodes and decrypts a base64-encoded value.
+func (cp *cipherParams) decrypt(value string) ([]byte, error) {
+	data, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data)%aes.BlockSize != 0 {
+		return nil, fmt.Errorf("Invalid length %d of encrypted value", len(data))
+	}
+
+	block, err := aes.NewCipher(cp.key)
+	if err != nil {
+		return nil, err
+	}
+
+	plain := make([]byte, len(data))
+	cipher.NewCBCDecrypter(block, cp.iv).CryptBlocks(plain, data)
+
+	return plain, nil
+}
+
+// decryptKey decrypts a key. Empty values yield no key.
+func (cp *cipherParams) decryptKey(value string) ([]byte, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	return cp.decrypt(value)
+}
+
+// decryptPassword decrypts a password. Passwords are prefixed with 8 random bytes and padded. The
+// last byte gives the length of the padding.
+func (cp *cipherParams) decryptPassword(value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+
+	data, err := cp.decrypt(value)
+	if err != nil {
+		return "", err
+	}
+
+	if len(data) == 0 {
+		return "", nil
+	}
+
+	padding := int(data[len(data)-1])
+	if padding == 0 || 8+padding > len(data) {
+		return "", fmt.Errorf("Invalid padding of encrypted password")
+	}
+
+	return string(data[8 : len(data)-padding]), nil
+}
+
+// signatureWriter serializes a keyring the way its signature is computed. Each string is prefixed
+// by its length.
+type signatureWriter struct {
+	bytes.Buffer
+}
+
+func (sw *signatureWriter) writeString(s string) {
+	sw.WriteByte(byte(len(s)))
+	sw.WriteString(s)
+}
+
+// attrsByName sorts attributes by their name.
+type attrsByName []xml.Attr
+
+func (attrs attrsByName) Len() int           { return len(attrs) }
+func (attrs attrsByName) Swap(i, j int)      { attrs[i], attrs[j] = attrs[j], attrs[i] }
+func (attrs attrsByName) Less(i, j int) bool { return attrs[i].Name.Local < attrs[j].Name.Local }
+
+// verifySignature checks the signature of the keyring contents. The serialization contains each
+// element with its attributes sorted by name, except for the namespace and the signature itself,
+// followed by the hashed password.
+func verifySignature(contents []byte, password string) error {
+	var sw signatureWriter
+	var signature string
+
+	d := xml.NewDecoder(bytes.NewReader(contents))
+
+	for {
+		token, err := d.RawToken()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+
+		switch token := token.(type) {
+		case xml.StartElement:
+			sw.WriteByte(1)
+			sw.writeString(token.Name.Local)
+
+			var attrs []xml.Attr
+			for _, attr := range token.Attr {
+				switch {
+				case attr.Name.Space == "" && attr.Name.Local == "Signature":
+					signature = attr.Value
+
+				case attr.Name.Space == "xmlns", attr.Name.Space == "" && attr.Name.Local == "xmlns":
+					// Namespace declarations are not signed.
+
+				default:
+					attrs = append(attrs, attr)
+				}
+			}
+
+			sort.Sort(attrsByName(attrs))
+
+			for _, attr := range attrs {
+				sw.writeString(attr.Name.Local)
+				sw.writeString(attr.Value)
+			}
+
+		case xml.EndElement:
+			sw.WriteByte(2)
+		}
+	}
+
+	passwordHash := sha256.Sum256([]byte(password))
+	sw.writeString(base64.StdEncoding.EncodeToString(passwordHash[:16]))
+
+	expected, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return err
+	}
+
+	actual := sha256.Sum256(sw.Bytes())
+	if !bytes.Equal(actual[:16], expected) {
+		return ErrSignature
+	}
+
+	return nil
+}
@@ -0,0 +1,31 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+/*
+Package keyring reads the keyring files (.knxkeys) that ETS exports for secure installations.
+
+A keyring contains the keys of the secure IP backbone, the credentials of IP Secure tunnels, the
+tool keys of Data Secure devices and the keys of secured group addresses. All keys and passwords
+within the file are encrypted with a key that is derived from the password given when exporting the
+keyring. The file is signed using the same password. Group addresses are best presented in the style
+configured for the project, which the project information provides.
+
+	kr, err := keyring.Open("my-project.knxkeys", "my-password")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	for _, group := range kr.GroupAddresses {
+		fmt.Println(info.FormatGroupAddr(group.Address), hex.EncodeToString(group.Key))
+	}
+
+Keyrings refer to group addresses and devices by their addresses. Link finds the corresponding
+elements of a project.
+
+	links := kr.Link(proj)
+	for id, group := range links.GroupAddresses {
+		fmt.Println(id, hex.EncodeToString(group.Key))
+	}
+*/
+package keyring
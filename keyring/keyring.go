@@ -0,0 +1,303 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package keyring
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/vapourismo/ets-go/ets"
+)
+
+const keyringNamespace = "http://knx.org/xml/keyring/1"
+
+// Backbone is the secure IP backbone of the installation.
+type Backbone struct {
+	MulticastAddress string
+
+	// Latency is the maximum latency of the backbone in milliseconds.
+	Latency uint
+	Key     []byte
+}
+
+// InterfaceGroup is a group address that a secure tunnel may access.
+type InterfaceGroup struct {
+	Address ets.GroupAddr
+	Senders []ets.IndividualAddr
+}
+
+// Interface contains the credentials of an interface, e.g. an IP Secure tunnel.
+type Interface struct {
+	// Type is the kind of interface, e.g. "Tunneling" or "USB".
+	Type string
+
+	// Host is the individual address of the device that provides the interface. It is only valid if
+	// HasHost is true.
+	Host    ets.IndividualAddr
+	HasHost bool
+
+	// IndividualAddress is the address that clients of the interface use. It is only valid if
+	// HasIndividualAddress is true.
+	IndividualAddress    ets.IndividualAddr
+	HasIndividualAddress bool
+
+	UserID         uint
+	Password       string
+	Authentication string
+	Groups         []InterfaceGroup
+}
+
+// GroupKey is the key of a secured group address.
+type GroupKey struct {
+	Address ets.GroupAddr
+	Key     []byte
+}
+
+// Device contains the keys of a secure device.
+type Device struct {
+	IndividualAddress  ets.IndividualAddr
+	SerialNumber       string
+	ToolKey            []byte
+	ManagementPassword string
+	Authentication     string
+	SequenceNumber     uint64
+}
+
+// Keyring contains the decrypted contents of a keyring file.
+type Keyring struct {
+	Project   string
+	CreatedBy string
+	Created   string
+
+	Backbones      []Backbone
+	Interfaces     []Interface
+	GroupAddresses []GroupKey
+	Devices        []Device
+}
+
+// parseOptionalAddr parses an individual address if it is present.
+func parseOptionalAddr(s string) (ets.IndividualAddr, bool, error) {
+	if s == "" {
+		return 0, false, nil
+	}
+
+	addr, err := ets.ParseIndividualAddr(s)
+	if err != nil {
+		return 0, false, err
+	}
+
+	return addr, true, nil
+}
+
+// parseOptionalUint parses an unsigned integer if it is present.
+func parseOptionalUint(s string, bits int) (uint64, error) {
+	if s == "" {
+		return 0, nil
+	}
+
+	return strconv.ParseUint(s, 10, bits)
+}
+
+type keyringDoc struct {
+	XMLName   xml.Name
+	Project   string `xml:",attr"`
+	CreatedBy string `xml:",attr"`
+	Created   string `xml:",attr"`
+	Backbone  []struct {
+		MulticastAddress string `xml:",attr"`
+		Latency          string `xml:",attr"`
+		Key              string `xml:",attr"`
+	}
+	Interface []struct {
+		Type              string `xml:",attr"`
+		Host              string `xml:",attr"`
+		IndividualAddress string `xml:",attr"`
+		UserID            string `xml:",attr"`
+		Password          string `xml:",attr"`
+		Authentication    string `xml:",attr"`
+		Group             []struct {
+			Address string `xml:",attr"`
+			Senders string `xml:",attr"`
+		}
+	}
+	GroupAddresses []struct {
+		Address string `xml:",attr"`
+		Key     string `xml:",attr"`
+	} `xml:"GroupAddresses>Group"`
+	Devices []struct {
+		IndividualAddress  string `xml:",attr"`
+		SerialNumber       string `xml:",attr"`
+		ToolKey            string `xml:",attr"`
+		ManagementPassword string `xml:",attr"`
+		Authentication     string `xml:",attr"`
+		SequenceNumber     string `xml:",attr"`
+	} `xml:"Devices>Device"`
+}
+
+func (doc *keyringDoc) decodeInterfaces(cp *cipherParams, kr *Keyring) error {
+	for _, docIface := range doc.Interface {
+		userID, err := parseOptionalUint(docIface.UserID, 8)
+		if err != nil {
+			return err
+		}
+
+		iface := Interface{Type: docIface.Type, UserID: uint(userID)}
+
+		if iface.Host, iface.HasHost, err = parseOptionalAddr(docIface.Host); err != nil {
+			return err
+		}
+
+		iface.IndividualAddress, iface.HasIndividualAddress, err = parseOptionalAddr(docIface.IndividualAddress)
+		if err != nil {
+			return err
+		}
+
+		if iface.Password, err = cp.decryptPassword(docIface.Password); err != nil {
+			return err
+		}
+
+		if iface.Authentication, err = cp.decryptPassword(docIface.Authentication); err != nil {
+			return err
+		}
+
+		for _, docGroup := range docIface.Group {
+			address, err := ets.ParseGroupAddr(docGroup.Address)
+			if err != nil {
+				return err
+			}
+
+			group := InterfaceGroup{Address: address}
+			for _, sender := range strings.Fields(docGroup.Senders) {
+				addr, err := ets.ParseIndividualAddr(sender)
+				if err != nil {
+					return err
+				}
+
+				group.Senders = append(group.Senders, addr)
+			}
+
+			iface.Groups = append(iface.Groups, group)
+		}
+
+		kr.Interfaces = append(kr.Interfaces, iface)
+	}
+
+	return nil
+}
+
+func (doc *keyringDoc) decode(cp *cipherParams) (*Keyring, error) {
+	kr := &Keyring{Project: doc.Project, CreatedBy: doc.CreatedBy, Created: doc.Created}
+
+	for _, docBackbone := range doc.Backbone {
+		latency, err := parseOptionalUint(docBackbone.Latency, 32)
+		if err != nil {
+			return nil, err
+		}
+
+		key, err := cp.decryptKey(docBackbone.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		kr.Backbones = append(kr.Backbones, Backbone{
+			MulticastAddress: docBackbone.MulticastAddress,
+			Latency:          uint(latency),
+			Key:              key,
+		})
+	}
+
+	if err := doc.decodeInterfaces(cp, kr); err != nil {
+		return nil, err
+	}
+
+	for _, docGroup := range doc.GroupAddresses {
+		address, err := ets.ParseGroupAddr(docGroup.Address)
+		if err != nil {
+			return nil, err
+		}
+
+		key, err := cp.decryptKey(docGroup.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		kr.GroupAddresses = append(kr.GroupAddresses, GroupKey{Address: address, Key: key})
+	}
+
+	for _, docDevice := range doc.Devices {
+		address, err := ets.ParseIndividualAddr(docDevice.IndividualAddress)
+		if err != nil {
+			return nil, err
+		}
+
+		sequenceNumber, err := parseOptionalUint(docDevice.SequenceNumber, 48)
+		if err != nil {
+			return nil, err
+		}
+
+		device := Device{
+			IndividualAddress: address,
+			SerialNumber:      docDevice.SerialNumber,
+			SequenceNumber:    sequenceNumber,
+		}
+
+		if device.ToolKey, err = cp.decryptKey(docDevice.ToolKey); err != nil {
+			return nil, err
+		}
+
+		if device.ManagementPassword, err = cp.decryptPassword(docDevice.ManagementPassword); err != nil {
+			return nil, err
+		}
+
+		if device.Authentication, err = cp.decryptPassword(docDevice.Authentication); err != nil {
+			return nil, err
+		}
+
+		kr.Devices = append(kr.Devices, device)
+	}
+
+	return kr, nil
+}
+
+// Decode reads a keyring, verifies its signature and decrypts its keys and passwords using the
+// password that has been given when exporting the keyring.
+func Decode(r io.Reader, password string) (*Keyring, error) {
+	contents, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc keyringDoc
+	if err := xml.NewDecoder(bytes.NewReader(contents)).Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	if doc.XMLName.Local != "Keyring" || doc.XMLName.Space != keyringNamespace {
+		return nil, fmt.Errorf("Unexpected element '%s' in namespace '%s'", doc.XMLName.Local, doc.XMLName.Space)
+	}
+
+	if err := verifySignature(contents, password); err != nil {
+		return nil, err
+	}
+
+	return doc.decode(newCipherParams(password, doc.Created))
+}
+
+// Open reads the keyring file at the given path.
+func Open(path, password string) (*Keyring, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	return Decode(file, password)
+}
@@ -0,0 +1,236 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package keyring
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io/ioutil"
+	"testing"
+
+	"github.com/vapourismo/ets-go/ets"
+)
+
+const (
+	testKeyring  = "testdata/test.knxkeys"
+	testPassword = "correct horse"
+)
+
+func mustParseIndividualAddr(t *testing.T, s string) ets.IndividualAddr {
+	addr, err := ets.ParseIndividualAddr(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return addr
+}
+
+func TestDecode(t *testing.T) {
+	kr, err := Open(testKeyring, testPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if kr.Project != "Test" || kr.CreatedBy != "ETS 6.1.0" || kr.Created != "2023-11-02T14:30:00" {
+		t.Errorf("Unexpected keyring attributes %q, %q, %q", kr.Project, kr.CreatedBy, kr.Created)
+	}
+
+	if len(kr.Backbones) != 1 {
+		t.Fatalf("Expected 1 backbone, got %d", len(kr.Backbones))
+	}
+
+	backbone := kr.Backbones[0]
+	if backbone.MulticastAddress != "224.0.23.12" || backbone.Latency != 1000 {
+		t.Errorf("Unexpected backbone %+v", backbone)
+	}
+
+	if key := hex.EncodeToString(backbone.Key); key != "00112233445566778899aabbccddeeff" {
+		t.Errorf("Unexpected backbone key %s", key)
+	}
+
+	if len(kr.Interfaces) != 2 {
+		t.Fatalf("Expected 2 interfaces, got %d", len(kr.Interfaces))
+	}
+
+	tunnel := kr.Interfaces[0]
+	switch {
+	case tunnel.Type != "Tunneling" || tunnel.UserID != 2:
+		t.Errorf("Unexpected tunnel %+v", tunnel)
+
+	case !tunnel.HasHost || tunnel.Host != mustParseIndividualAddr(t, "1.1.0"):
+		t.Errorf("Unexpected tunnel host %v", tunnel.Host)
+
+	case !tunnel.HasIndividualAddress || tunnel.IndividualAddress != mustParseIndividualAddr(t, "1.1.250"):
+		t.Errorf("Unexpected tunnel address %v", tunnel.IndividualAddress)
+
+	case tunnel.Password != "tunnel-pw" || tunnel.Authentication != "auth-code":
+		t.Errorf("Unexpected tunnel credentials %q, %q", tunnel.Password, tunnel.Authentication)
+
+	case len(tunnel.Groups) != 1 || tunnel.Groups[0].Address != ets.NewGroupAddr3(1, 0, 1) ||
+		len(tunnel.Groups[0].Senders) != 2:
+		t.Errorf("Unexpected tunnel groups %+v", tunnel.Groups)
+	}
+
+	if usb := kr.Interfaces[1]; usb.Type != "USB" || usb.HasHost || usb.HasIndividualAddress || usb.Password != "" {
+		t.Errorf("Unexpected USB interface %+v", usb)
+	}
+
+	groupKeys := map[ets.GroupAddr]string{
+		ets.NewGroupAddr3(1, 0, 1): "a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1",
+		ets.NewGroupAddr3(1, 0, 2): "a2a2a2a2a2a2a2a2a2a2a2a2a2a2a2a2",
+	}
+
+	if len(kr.GroupAddresses) != len(groupKeys) {
+		t.Fatalf("Expected %d group keys, got %d", len(groupKeys), len(kr.GroupAddresses))
+	}
+
+	for _, group := range kr.GroupAddresses {
+		if key := hex.EncodeToString(group.Key); key != groupKeys[group.Address] {
+			t.Errorf("Unexpected key %s for group address %v", key, group.Address)
+		}
+	}
+
+	if len(kr.Devices) != 2 {
+		t.Fatalf("Expected 2 devices, got %d", len(kr.Devices))
+	}
+
+	device := kr.Devices[0]
+	switch {
+	case device.IndividualAddress != mustParseIndividualAddr(t, "1.1.0") || device.SerialNumber != "00FA10010701":
+		t.Errorf("Unexpected device %+v", device)
+
+	case hex.EncodeToString(device.ToolKey) != "10101010101010101010101010101010":
+		t.Errorf("Unexpected tool key %x", device.ToolKey)
+
+	case device.ManagementPassword != "mgmt" || device.Authentication != "device-auth":
+		t.Errorf("Unexpected device credentials %q, %q", device.ManagementPassword, device.Authentication)
+
+	case device.SequenceNumber != 1<<48-1:
+		t.Errorf("Unexpected sequence number %d", device.SequenceNumber)
+	}
+
+	if device := kr.Devices[1]; device.ManagementPassword != "" || device.SequenceNumber != 7 {
+		t.Errorf("Unexpected device %+v", device)
+	}
+}
+
+func TestDecodeWrongPassword(t *testing.T) {
+	if _, err := Open(testKeyring, "wrong horse"); err != ErrSignature {
+		t.Errorf("Expected ErrSignature, got %v", err)
+	}
+}
+
+func TestDecodeTampered(t *testing.T) {
+	contents, err := ioutil.ReadFile(testKeyring)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tampered := bytes.Replace(contents, []byte(`Latency="1000"`), []byte(`Latency="2000"`), 1)
+	if _, err := Decode(bytes.NewReader(tampered), testPassword); err != ErrSignature {
+		t.Errorf("Expected ErrSignature, got %v", err)
+	}
+}
+
+func TestCipherParams(t *testing.T) {
+	// Reference values computed with Python's hashlib.pbkdf2_hmac and hashlib.sha256.
+	cp := newCipherParams(testPassword, "2023-11-02T14:30:00")
+
+	if key := hex.EncodeToString(cp.key); key != "9686597ecebe80d007c7230c7240f37e" {
+		t.Errorf("Unexpected key %s", key)
+	}
+
+	if iv := hex.EncodeToString(cp.iv); iv != "fa9019784ffbc6d373581a4dd3070fda" {
+		t.Errorf("Unexpected initialization vector %s", iv)
+	}
+}
+
+func TestDecodeEscaped(t *testing.T) {
+	// The signature of this keyring has been computed by a separate implementation based on Python's
+	// xml.etree. The keyring contains escaped characters, comments, attributes in arbitrary order
+	// and explicit end tags.
+	kr, err := Open("testdata/escaped.knxkeys", testPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if kr.Project != `R&D "Lab" <1>` || kr.CreatedBy != "ETS 5.7.7" {
+		t.Errorf("Unexpected keyring attributes %q, %q", kr.Project, kr.CreatedBy)
+	}
+
+	if len(kr.Backbones) != 1 || kr.Backbones[0].Latency != 800 || kr.Backbones[0].Key != nil {
+		t.Errorf("Unexpected backbones %+v", kr.Backbones)
+	}
+
+	if len(kr.Interfaces) != 1 || kr.Interfaces[0].UserID != 3 || len(kr.Interfaces[0].Groups) != 0 {
+		t.Errorf("Unexpected interfaces %+v", kr.Interfaces)
+	}
+
+	if len(kr.Devices) != 1 || kr.Devices[0].SerialNumber != "00FA10010702" {
+		t.Errorf("Unexpected devices %+v", kr.Devices)
+	}
+}
+
+func TestDecryptPassword(t *testing.T) {
+	cp := newCipherParams(testPassword, "2023-11-02T14:30:00")
+
+	// The ciphertext decrypts to a block without a valid padding length.
+	if _, err := cp.decryptPassword("2QIw4y/XzO/2KTETUJcAyQ=="); err == nil {
+		t.Error("Expected an error for invalid padding")
+	}
+
+	if _, err := cp.decryptPassword("AAAA"); err == nil {
+		t.Error("Expected an error for a truncated value")
+	}
+}
+
+func TestLink(t *testing.T) {
+	kr, err := Open(testKeyring, testPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proj := &ets.Project{
+		Installations: []ets.Installation{{
+			Topology: []ets.Area{{
+				Address: 1,
+				Lines: []ets.Line{{
+					Address: 1,
+					Devices: []ets.DeviceInstance{
+						{ID: "P-0001-0_DI-1", Address: 0, HasAddress: true},
+						{ID: "P-0001-0_DI-2", Address: 5, HasAddress: true},
+					},
+				}},
+			}},
+			GroupAddresses: []ets.GroupRange{{
+				Addresses: []ets.GroupAddress{
+					{ID: "P-0001-0_GA-1", Address: ets.NewGroupAddr3(1, 0, 1)},
+					{ID: "P-0001-0_GA-2", Address: ets.NewGroupAddr3(1, 0, 3)},
+				},
+			}},
+		}},
+	}
+
+	links := kr.Link(proj)
+
+	if group := links.GroupAddresses["P-0001-0_GA-1"]; group == nil || group.Address != ets.NewGroupAddr3(1, 0, 1) {
+		t.Errorf("Unexpected link for group address: %+v", group)
+	}
+
+	if _, ok := links.GroupAddresses["P-0001-0_GA-2"]; ok {
+		t.Error("Group address without key has been linked")
+	}
+
+	if device := links.Devices["P-0001-0_DI-1"]; device == nil || device.ManagementPassword != "mgmt" {
+		t.Errorf("Unexpected link for device: %+v", device)
+	}
+
+	if _, ok := links.Devices["P-0001-0_DI-2"]; ok {
+		t.Error("Device without keys has been linked")
+	}
+
+	if ifaces := links.Interfaces["P-0001-0_DI-1"]; len(ifaces) != 1 || ifaces[0].Type != "Tunneling" {
+		t.Errorf("Unexpected interfaces %+v", ifaces)
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package keyring
+
+import "github.com/vapourismo/ets-go/ets"
+
+// Links maps the entries of a keyring onto the elements of a project.
+type Links struct {
+	GroupAddresses map[ets.GroupAddressID]*GroupKey
+	Devices        map[ets.DeviceInstanceID]*Device
+
+	// Interfaces maps devices onto the interfaces that they host.
+	Interfaces map[ets.DeviceInstanceID][]*Interface
+}
+
+// Link finds the group addresses and devices of the project that the entries of the keyring refer
+// to. Group addresses are matched by their address, devices by their individual address. Entries
+// without a counterpart in the project are not linked.
+func (kr *Keyring) Link(proj *ets.Project) *Links {
+	links := &Links{
+		GroupAddresses: map[ets.GroupAddressID]*GroupKey{},
+		Devices:        map[ets.DeviceInstanceID]*Device{},
+		Interfaces:     map[ets.DeviceInstanceID][]*Interface{},
+	}
+
+	groups := map[ets.GroupAddr]*GroupKey{}
+	for n := range kr.GroupAddresses {
+		groups[kr.GroupAddresses[n].Address] = &kr.GroupAddresses[n]
+	}
+
+	devices := map[ets.IndividualAddr]*Device{}
+	for n := range kr.Devices {
+		devices[kr.Devices[n].IndividualAddress] = &kr.Devices[n]
+	}
+
+	interfaces := map[ets.IndividualAddr][]*Interface{}
+	for n := range kr.Interfaces {
+		if iface := &kr.Interfaces[n]; iface.HasHost {
+			interfaces[iface.Host] = append(interfaces[iface.Host], iface)
+		}
+	}
+
+	for i := range proj.Installations {
+		inst := &proj.Installations[i]
+
+		inst.WalkGroupAddresses(func(ranges []*ets.GroupRange, addr *ets.GroupAddress) {
+			if group, ok := groups[addr.Address]; ok {
+				links.GroupAddresses[addr.ID] = group
+			}
+		})
+
+		inst.WalkDevices(func(area *ets.Area, line *ets.Line, device *ets.DeviceInstance) {
+			if !device.HasAddress {
+				return
+			}
+
+			address := ets.DeviceAddr(area, line, device)
+
+			if keys, ok := devices[address]; ok {
+				links.Devices[device.ID] = keys
+			}
+
+			if ifaces, ok := interfaces[address]; ok {
+				links.Interfaces[device.ID] = ifaces
+			}
+		})
+	}
+
+	return links
+}
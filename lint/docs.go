@@ -0,0 +1,15 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+/*
+Package lint checks ETS projects for common mistakes.
+
+Rules are run over a decoded project and the manufacturer data of the same export archive. Each
+rule reports findings which refer to the offending elements by their IDs.
+
+	findings := lint.Lint(proj, manufacturers, info.GroupAddressStyle)
+	for _, finding := range findings {
+		fmt.Println(finding)
+	}
+*/
+package lint
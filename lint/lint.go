@@ -0,0 +1,132 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package lint
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vapourismo/ets-go/ets"
+)
+
+// Severity indicates how severe a finding is.
+type Severity int
+
+const (
+	// Info findings point out things that are unusual but most likely intended.
+	Info Severity = iota
+
+	// Warning findings point out things that are likely to be a mistake.
+	Warning
+
+	// Error findings point out things that will not work.
+	Error
+)
+
+// String returns the name of the severity.
+func (s Severity) String() string {
+	switch s {
+	case Info:
+		return "info"
+
+	case Warning:
+		return "warning"
+
+	case Error:
+		return "error"
+
+	default:
+		return fmt.Sprintf("severity(%d)", int(s))
+	}
+}
+
+// Finding is something a rule has found.
+type Finding struct {
+	Rule     string
+	Severity Severity
+	Message  string
+
+	// Elements contains the IDs of the elements that the finding refers to.
+	Elements []string
+}
+
+// String generates a human-readable representation of the finding.
+func (f Finding) String() string {
+	return fmt.Sprintf("%s: %s [%s] (%s)", f.Severity, f.Message, f.Rule, strings.Join(f.Elements, ", "))
+}
+
+// Context contains the data that rules operate on.
+type Context struct {
+	Project  *ets.Project
+	Index    *ets.GroupAddressIndex
+	Resolver *ets.ComObjectResolver
+
+	// Style is the style in which group addresses are presented in messages.
+	Style ets.GroupAddrStyle
+
+	comObjects map[ets.ComObjectKey]*ets.EffectiveComObject
+}
+
+// NewContext prepares the given project and manufacturer data for linting. Group addresses are
+// formatted in the given style.
+func NewContext(proj *ets.Project, manufacturers []*ets.ManufacturerData, style ets.GroupAddrStyle) *Context {
+	ctx := &Context{
+		Project:    proj,
+		Index:      ets.NewGroupAddressIndex(proj),
+		Resolver:   ets.NewComObjectResolver(manufacturers),
+		Style:      style,
+		comObjects: map[ets.ComObjectKey]*ets.EffectiveComObject{},
+	}
+
+	for i := range proj.Installations {
+		proj.Installations[i].WalkDevices(func(area *ets.Area, line *ets.Line, device *ets.DeviceInstance) {
+			for n := range device.ComObjects {
+				comObj := ctx.Resolver.Resolve(device, &device.ComObjects[n])
+				key := ets.ComObjectKey{Device: device.ID, RefID: device.ComObjects[n].RefID}
+				ctx.comObjects[key] = &comObj
+			}
+		})
+	}
+
+	return ctx
+}
+
+// ComObject returns the effective communication object that is part of the given connection.
+func (ctx *Context) ComObject(conn ets.Connection) *ets.EffectiveComObject {
+	return ctx.comObjects[ets.ComObjectKey{Device: conn.Device.ID, RefID: conn.ComObject.RefID}]
+}
+
+// Reporter is used by rules to report findings.
+type Reporter func(message string, elements ...string)
+
+// Rule is a check that is performed on a project.
+type Rule struct {
+	Name        string
+	Description string
+	Severity    Severity
+	Check       func(ctx *Context, report Reporter)
+}
+
+// Run the given rules. Findings are returned in the order of the rules.
+func Run(ctx *Context, rules []Rule) []Finding {
+	var findings []Finding
+
+	for _, rule := range rules {
+		rule.Check(ctx, func(message string, elements ...string) {
+			findings = append(findings, Finding{
+				Rule:     rule.Name,
+				Severity: rule.Severity,
+				Message:  message,
+				Elements: elements,
+			})
+		})
+	}
+
+	return findings
+}
+
+// Lint runs the default rules over the project. Group addresses are formatted in the given style.
+func Lint(proj *ets.Project, manufacturers []*ets.ManufacturerData, style ets.GroupAddrStyle) []Finding {
+	return Run(NewContext(proj, manufacturers, style), DefaultRules())
+}
@@ -0,0 +1,313 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package lint
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vapourismo/ets-go/ets"
+)
+
+// DefaultRules returns the built-in rules.
+func DefaultRules() []Rule {
+	return []Rule{
+		NoSender,
+		NoReceiver,
+		MissingIndividualAddress,
+		DuplicateIndividualAddress,
+		DatapointTypeMismatch,
+		PointlessConnection,
+	}
+}
+
+// walkGroupAddresses calls fn for each group address in the project.
+func walkGroupAddresses(ctx *Context, fn func(addr *ets.GroupAddress)) {
+	for i := range ctx.Project.Installations {
+		ctx.Project.Installations[i].WalkGroupAddresses(func(_ []*ets.GroupRange, addr *ets.GroupAddress) {
+			fn(addr)
+		})
+	}
+}
+
+// walkDevices calls fn for each device in the project.
+func walkDevices(ctx *Context, fn func(area *ets.Area, line *ets.Line, device *ets.DeviceInstance)) {
+	for i := range ctx.Project.Installations {
+		ctx.Project.Installations[i].WalkDevices(fn)
+	}
+}
+
+// canSend determines whether the communication object transmits on its sending group address.
+// Communication objects without manufacturer data are assumed to be able to.
+func canSend(comObj *ets.EffectiveComObject) bool {
+	return comObj == nil || !comObj.Resolved() || comObj.TransmitFlag
+}
+
+// canReceive determines whether the communication object accepts values from the bus.
+// Communication objects without manufacturer data are assumed to be able to.
+func canReceive(comObj *ets.EffectiveComObject) bool {
+	return comObj == nil || !comObj.Resolved() || comObj.WriteFlag || comObj.UpdateFlag
+}
+
+func isSender(ctx *Context, conn ets.Connection) bool {
+	return !conn.Receive && canSend(ctx.ComObject(conn))
+}
+
+func connectionElements(addr *ets.GroupAddress, conns []ets.Connection) []string {
+	elements := []string{string(addr.ID)}
+
+	for _, conn := range conns {
+		elements = append(elements, string(conn.Device.ID))
+	}
+
+	return elements
+}
+
+// NoSender reports group addresses which have communication objects listening on them, but no
+// communication object that sends to them.
+var NoSender = Rule{
+	Name:        "no-sender",
+	Description: "Group address has no sender",
+	Severity:    Warning,
+	Check: func(ctx *Context, report Reporter) {
+		walkGroupAddresses(ctx, func(addr *ets.GroupAddress) {
+			conns := ctx.Index.Connections(addr.ID)
+			if len(conns) == 0 {
+				return
+			}
+
+			for _, conn := range conns {
+				if isSender(ctx, conn) {
+					return
+				}
+			}
+
+			report(
+				fmt.Sprintf("Group address %s (%s) has no sender", addr.Address.Format(ctx.Style), addr.Name),
+				connectionElements(addr, conns)...,
+			)
+		})
+	},
+}
+
+// sameComObject determines whether both connections belong to the same communication object.
+func sameComObject(a, b ets.Connection) bool {
+	return a.Device == b.Device && a.ComObject == b.ComObject
+}
+
+// NoReceiver reports group addresses which have communication objects sending to them, but no
+// other communication object that receives from them. Any other communication object that accepts
+// values counts as a receiver, regardless of whether it is connected as a sender itself.
+var NoReceiver = Rule{
+	Name:        "no-receiver",
+	Description: "Group address has no receiver",
+	Severity:    Warning,
+	Check: func(ctx *Context, report Reporter) {
+		walkGroupAddresses(ctx, func(addr *ets.GroupAddress) {
+			conns := ctx.Index.Connections(addr.ID)
+
+			for _, sender := range conns {
+				if !isSender(ctx, sender) {
+					continue
+				}
+
+				received := false
+				for _, conn := range conns {
+					if !sameComObject(sender, conn) && canReceive(ctx.ComObject(conn)) {
+						received = true
+						break
+					}
+				}
+
+				if !received {
+					report(
+						fmt.Sprintf("Group address %s (%s) has no receiver", addr.Address.Format(ctx.Style), addr.Name),
+						connectionElements(addr, conns)...,
+					)
+					return
+				}
+			}
+		})
+	},
+}
+
+// MissingIndividualAddress reports devices that have not been assigned an individual address.
+var MissingIndividualAddress = Rule{
+	Name:        "missing-individual-address",
+	Description: "Device has no individual address",
+	Severity:    Error,
+	Check: func(ctx *Context, report Reporter) {
+		walkDevices(ctx, func(_ *ets.Area, line *ets.Line, device *ets.DeviceInstance) {
+			if !device.HasAddress {
+				report(fmt.Sprintf("Device '%s' has no individual address", device.Name), string(device.ID))
+			}
+		})
+	},
+}
+
+// DuplicateIndividualAddress reports devices on the same line that share an individual address.
+var DuplicateIndividualAddress = Rule{
+	Name:        "duplicate-individual-address",
+	Description: "Devices on a line share an individual address",
+	Severity:    Error,
+	Check: func(ctx *Context, report Reporter) {
+		var (
+			currentLine *ets.Line
+			addresses   []ets.IndividualAddr
+			devices     map[ets.IndividualAddr][]string
+		)
+
+		flush := func() {
+			for _, address := range addresses {
+				if len(devices[address]) > 1 {
+					report(
+						fmt.Sprintf("Devices on line '%s' share address %v", currentLine.Name, address),
+						append([]string{string(currentLine.ID)}, devices[address]...)...,
+					)
+				}
+			}
+		}
+
+		walkDevices(ctx, func(area *ets.Area, line *ets.Line, device *ets.DeviceInstance) {
+			if line != currentLine {
+				if currentLine != nil {
+					flush()
+				}
+
+				currentLine = line
+				addresses = nil
+				devices = map[ets.IndividualAddr][]string{}
+			}
+
+			if !device.HasAddress {
+				return
+			}
+
+			address := ets.DeviceAddr(area, line, device)
+			if _, ok := devices[address]; !ok {
+				addresses = append(addresses, address)
+			}
+
+			devices[address] = append(devices[address], string(device.ID))
+		})
+
+		if currentLine != nil {
+			flush()
+		}
+	},
+}
+
+// mainTypes extracts the main numbers of the datapoint types listed in s, e.g. "DPST-9-1 DPT-1"
+// yields 9 and 1.
+func mainTypes(s string) map[uint]bool {
+	types := map[uint]bool{}
+
+	dpts, _ := ets.ParseDatapointTypes(s)
+	for _, dpt := range dpts {
+		types[dpt.Main] = true
+	}
+
+	return types
+}
+
+// DatapointTypeMismatch reports group addresses that connect communication objects with
+// incompatible datapoint types.
+var DatapointTypeMismatch = Rule{
+	Name:        "datapoint-type-mismatch",
+	Description: "Communication objects sharing a group address have incompatible datapoint types",
+	Severity:    Error,
+	Check: func(ctx *Context, report Reporter) {
+		walkGroupAddresses(ctx, func(addr *ets.GroupAddress) {
+			conns := ctx.Index.Connections(addr.ID)
+
+			var (
+				common  map[uint]bool
+				typed   []ets.Connection
+				dptList []string
+			)
+
+			for _, conn := range conns {
+				comObj := ctx.ComObject(conn)
+				if comObj == nil || comObj.DatapointType == "" {
+					continue
+				}
+
+				types := mainTypes(comObj.DatapointType)
+				if len(types) == 0 {
+					continue
+				}
+
+				if common == nil {
+					common = types
+				} else {
+					for main := range common {
+						if !types[main] {
+							delete(common, main)
+						}
+					}
+				}
+
+				typed = append(typed, conn)
+				dptList = append(dptList, comObj.DatapointType)
+			}
+
+			if len(typed) > 1 && len(common) == 0 {
+				report(
+					fmt.Sprintf(
+						"Group address %s (%s) connects incompatible datapoint types %s",
+						addr.Address.Format(ctx.Style),
+						addr.Name,
+						strings.Join(dptList, ", "),
+					),
+					connectionElements(addr, typed)...,
+				)
+			}
+		})
+	},
+}
+
+// PointlessConnection reports connections between communication objects and group addresses that
+// have no effect due to the flags of the communication object.
+var PointlessConnection = Rule{
+	Name:        "pointless-connection",
+	Description: "Flags of a communication object make its connection pointless",
+	Severity:    Warning,
+	Check: func(ctx *Context, report Reporter) {
+		walkDevices(ctx, func(_ *ets.Area, line *ets.Line, device *ets.DeviceInstance) {
+			for n := range device.ComObjects {
+				inst := &device.ComObjects[n]
+
+				for _, connector := range inst.Connectors {
+					conn := ets.Connection{Device: device, ComObject: inst, Receive: connector.Receive}
+					comObj := ctx.ComObject(conn)
+					if comObj == nil || !comObj.Resolved() {
+						continue
+					}
+
+					elements := []string{string(device.ID), string(inst.RefID), string(connector.RefID)}
+
+					switch {
+					case !comObj.CommunicationFlag:
+						report(
+							fmt.Sprintf("Communication object '%s' is connected but its communication flag is disabled", comObj.Text),
+							elements...,
+						)
+
+					case connector.Receive && !canReceive(comObj):
+						report(
+							fmt.Sprintf("Communication object '%s' listens on a group address but can not receive", comObj.Text),
+							elements...,
+						)
+
+					case !connector.Receive && !comObj.TransmitFlag && !comObj.ReadFlag && !canReceive(comObj):
+						report(
+							fmt.Sprintf("Communication object '%s' can neither send, respond nor receive", comObj.Text),
+							elements...,
+						)
+					}
+				}
+			}
+		})
+	},
+}
@@ -0,0 +1,153 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package lint
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vapourismo/ets-go/ets"
+)
+
+const testProgram = "M-0001_A-0001-01-0000"
+
+// testComObject describes a communication object that is connected to the group address
+// "P-0001-0_GA-1".
+type testComObject struct {
+	// ref is empty for communication objects without manufacturer data.
+	ref     string
+	receive bool
+}
+
+func testManufacturers() []*ets.ManufacturerData {
+	objects := []struct {
+		name                 string
+		transmit, write, upd bool
+	}{
+		{"T", true, false, false},
+		{"W", false, true, false},
+		{"TW", true, true, false},
+		{"TU", true, false, true},
+	}
+
+	prog := ets.ApplicationProgram{ID: testProgram}
+	for _, obj := range objects {
+		prog.Objects = append(prog.Objects, ets.ComObject{
+			ID:           ets.ComObjectID(testProgram + "_O-" + obj.name),
+			TransmitFlag: obj.transmit,
+			WriteFlag:    obj.write,
+			UpdateFlag:   obj.upd,
+		})
+
+		prog.ObjectRefs = append(prog.ObjectRefs, ets.ComObjectRef{
+			ID:    ets.ComObjectRefID(testProgram + "_O-" + obj.name + "_R-1"),
+			RefID: ets.ComObjectID(testProgram + "_O-" + obj.name),
+		})
+	}
+
+	return []*ets.ManufacturerData{{Manufacturer: "M-0001", Programs: []ets.ApplicationProgram{prog}}}
+}
+
+func testProject(comObjs []testComObject) *ets.Project {
+	line := ets.Line{ID: "P-0001-0_L-1", Address: 1}
+
+	for n, comObj := range comObjs {
+		ref := ets.ComObjectRefID("O-unknown_R-1")
+		if comObj.ref != "" {
+			ref = ets.ComObjectRefID(testProgram + "_O-" + comObj.ref + "_R-1")
+		}
+
+		line.Devices = append(line.Devices, ets.DeviceInstance{
+			ID:         ets.DeviceInstanceID("P-0001-0_DI-" + string(rune('1'+n))),
+			Address:    ets.DeviceNumber(n + 1),
+			HasAddress: true,
+			ComObjects: []ets.ComObjectInstanceRef{{
+				RefID:      ref,
+				Connectors: []ets.Connector{{Receive: comObj.receive, RefID: "P-0001-0_GA-1"}},
+			}},
+		})
+	}
+
+	return &ets.Project{
+		ID: "P-0001",
+		Installations: []ets.Installation{{
+			Topology: []ets.Area{{ID: "P-0001-0_A-1", Address: 1, Lines: []ets.Line{line}}},
+			GroupAddresses: []ets.GroupRange{{
+				ID:        "P-0001-0_GR-1",
+				Addresses: []ets.GroupAddress{{ID: "P-0001-0_GA-1", Address: 1}},
+			}},
+		}},
+	}
+}
+
+func TestNoReceiver(t *testing.T) {
+	tests := []struct {
+		name    string
+		comObjs []testComObject
+		report  bool
+	}{
+		{"sender and receiver", []testComObject{{ref: "T"}, {ref: "W", receive: true}}, false},
+		{"sender only", []testComObject{{ref: "T"}}, true},
+		{"sender and other sender", []testComObject{{ref: "T"}, {ref: "T"}}, true},
+		{"two T+W objects", []testComObject{{ref: "TW"}, {ref: "TW"}}, false},
+		{"actuator and status object", []testComObject{{ref: "TW"}, {ref: "TU"}}, false},
+		{"status object without receiver", []testComObject{{ref: "TU"}, {ref: "T"}}, true},
+		{"without manufacturer data", []testComObject{{}, {}}, false},
+		{"without manufacturer data alone", []testComObject{{}}, true},
+		{"receivers only", []testComObject{{ref: "W", receive: true}}, false},
+	}
+
+	for _, test := range tests {
+		ctx := NewContext(testProject(test.comObjs), testManufacturers(), ets.GroupAddrStyleThreeLevel)
+		findings := Run(ctx, []Rule{NoReceiver})
+
+		if report := len(findings) > 0; report != test.report {
+			t.Errorf("%s: expected report %v, got %v", test.name, test.report, findings)
+		}
+	}
+}
+
+func TestNoReceiverStyle(t *testing.T) {
+	tests := []struct {
+		style ets.GroupAddrStyle
+		want  string
+	}{
+		{ets.GroupAddrStyleThreeLevel, "Group address 0/0/1 () has no receiver"},
+		{ets.GroupAddrStyleTwoLevel, "Group address 0/1 () has no receiver"},
+		{ets.GroupAddrStyleFree, "Group address 1 () has no receiver"},
+	}
+
+	for _, test := range tests {
+		ctx := NewContext(testProject([]testComObject{{ref: "T"}}), testManufacturers(), test.style)
+		findings := Run(ctx, []Rule{NoReceiver})
+
+		if len(findings) != 1 || findings[0].Message != test.want {
+			t.Errorf("%v: got %v, want message %q", test.style, findings, test.want)
+		}
+	}
+}
+
+func TestDuplicateIndividualAddress(t *testing.T) {
+	proj := testProject([]testComObject{{ref: "T"}, {ref: "W"}, {ref: "TW"}})
+
+	devices := proj.Installations[0].Topology[0].Lines[0].Devices
+	devices[0].Address = 5
+	devices[1].Address = 5
+
+	ctx := NewContext(proj, testManufacturers(), ets.GroupAddrStyleThreeLevel)
+	findings := Run(ctx, []Rule{DuplicateIndividualAddress})
+	if len(findings) != 1 {
+		t.Fatalf("Got findings %v, want exactly one", findings)
+	}
+
+	want := "Devices on line '' share address 1.1.5"
+	if findings[0].Message != want {
+		t.Errorf("Got message %q, want %q", findings[0].Message, want)
+	}
+
+	wantElements := []string{"P-0001-0_L-1", "P-0001-0_DI-1", "P-0001-0_DI-2"}
+	if !reflect.DeepEqual(findings[0].Elements, wantElements) {
+		t.Errorf("Got elements %v, want %v", findings[0].Elements, wantElements)
+	}
+}
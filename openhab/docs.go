@@ -0,0 +1,19 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+/*
+Package openhab generates openHAB KNX things and items from an ETS project.
+
+Each device becomes a thing and each of its connected communication objects becomes a channel of
+that thing. The channel type is derived from the datapoint type of the connected group addresses or,
+if they have none, of the communication object. The sending group address of a communication object
+is its main group address, group addresses that it only listens on are added as listening group
+addresses.
+
+	config := openhab.Generate(proj, manufacturers, info.GroupAddressStyle)
+	config.Bridge.Params = append(config.Bridge.Params, openhab.Param{Key: "ipAddress", Value: "192.168.0.10"})
+
+	config.WriteThings(thingsFile)
+	config.WriteItems(itemsFile)
+*/
+package openhab
@@ -0,0 +1,249 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package openhab
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/vapourismo/ets-go/ets"
+)
+
+// Param is a configuration parameter of a bridge, thing or channel.
+type Param struct {
+	Key   string
+	Value string
+}
+
+// Bridge is the KNX bridge that the things are attached to.
+type Bridge struct {
+	ID     string
+	Label  string
+	Params []Param
+}
+
+// Channel is a channel of a thing.
+type Channel struct {
+	ID       string
+	Label    string
+	Type     string
+	ItemType string
+	Params   []Param
+}
+
+// Thing is a KNX device.
+type Thing struct {
+	ID       string
+	Label    string
+	Params   []Param
+	Channels []Channel
+}
+
+// Config contains the generated things.
+type Config struct {
+	Bridge Bridge
+	Things []Thing
+
+	// Unmapped contains the connected communication objects that could not be mapped to a channel.
+	Unmapped []ets.EffectiveComObject
+}
+
+// channelType describes how a datapoint type is mapped onto a channel.
+type channelType struct {
+	channel  string
+	param    string
+	itemType string
+}
+
+// channelTypes maps datapoint types onto channel types. Keys without a sub number apply to all
+// datapoint types with the same main number.
+var channelTypes = map[string]channelType{
+	"1.008":   {"rollershutter", "upDown", "Rollershutter"},
+	"1.007":   {"rollershutter", "stopMove", "Rollershutter"},
+	"1.010":   {"rollershutter", "stopMove", "Rollershutter"},
+	"1.009":   {"contact", "ga", "Contact"},
+	"1.019":   {"contact", "ga", "Contact"},
+	"1":       {"switch", "ga", "Switch"},
+	"3.007":   {"dimmer", "increaseDecrease", "Dimmer"},
+	"5.001":   {"dimmer", "position", "Dimmer"},
+	"5":       {"number", "ga", "Number"},
+	"6":       {"number", "ga", "Number"},
+	"7":       {"number", "ga", "Number"},
+	"8":       {"number", "ga", "Number"},
+	"9":       {"number", "ga", "Number"},
+	"10":      {"datetime", "ga", "DateTime"},
+	"11":      {"datetime", "ga", "DateTime"},
+	"12":      {"number", "ga", "Number"},
+	"13":      {"number", "ga", "Number"},
+	"14":      {"number", "ga", "Number"},
+	"16":      {"string", "ga", "String"},
+	"17":      {"number", "ga", "Number"},
+	"19":      {"datetime", "ga", "DateTime"},
+	"232.600": {"color", "hsb", "Color"},
+}
+
+// parseDatapointType parses the first datapoint type listed in s, e.g. "DPST-9-1".
+func parseDatapointType(s string) (ets.DatapointType, bool) {
+	dpts, err := ets.ParseDatapointTypes(s)
+	if err != nil || len(dpts) == 0 {
+		return ets.DatapointType{}, false
+	}
+
+	return dpts[0], true
+}
+
+// datapointTypeOf determines the datapoint type of a communication object. The datapoint types of
+// the connected group addresses take precedence over the one of the communication object.
+func datapointTypeOf(comObj *ets.EffectiveComObject, idx *ets.GroupAddressIndex) (ets.DatapointType, bool) {
+	for _, conn := range comObj.Connectors {
+		if addr := idx.GroupAddress(conn.RefID); addr != nil {
+			if dpt, ok := parseDatapointType(addr.DatapointType); ok {
+				return dpt, true
+			}
+		}
+	}
+
+	return parseDatapointType(comObj.DatapointType)
+}
+
+// sanitizeID turns s into a valid ID for things, channels and items.
+func sanitizeID(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return unicode.ToLower(r)
+		}
+
+		return '_'
+	}, s)
+}
+
+// formatGroupAddresses builds the group address configuration of a channel, e.g.
+// "1.001:1/0/0+<1/0/1".
+func formatGroupAddresses(
+	comObj *ets.EffectiveComObject,
+	dpt ets.DatapointType,
+	idx *ets.GroupAddressIndex,
+	style ets.GroupAddrStyle,
+) string {
+	var main string
+	var listening []string
+
+	for _, conn := range comObj.Connectors {
+		addr := idx.GroupAddress(conn.RefID)
+		if addr == nil {
+			continue
+		}
+
+		formatted := addr.Address.Format(style)
+		if !conn.Receive && main == "" {
+			main = formatted
+		} else {
+			listening = append(listening, formatted)
+		}
+	}
+
+	addresses := listening
+	if main != "" {
+		addresses = append([]string{main}, listening...)
+	}
+
+	if len(addresses) == 0 {
+		return ""
+	}
+
+	// Group addresses marked with '<' are read when the binding starts.
+	if comObj.ReadOnInitFlag {
+		readIndex := 0
+		if main != "" && len(addresses) > 1 {
+			readIndex = 1
+		}
+
+		addresses[readIndex] = "<" + addresses[readIndex]
+	}
+
+	// openHAB requires a sub type in order to decode values.
+	if !dpt.HasSub {
+		return strings.Join(addresses, "+")
+	}
+
+	return dpt.String() + ":" + strings.Join(addresses, "+")
+}
+
+func channelLabel(comObj *ets.EffectiveComObject) string {
+	for _, label := range []string{comObj.Text, comObj.FunctionText, comObj.Name} {
+		if label != "" {
+			return label
+		}
+	}
+
+	return string(comObj.Instance.RefID)
+}
+
+// Generate things and items for the given project. Group addresses are formatted in the given
+// style. The bridge is a KNX/IP tunnel whose parameters need to be completed by the caller.
+func Generate(proj *ets.Project, manufacturers []*ets.ManufacturerData, style ets.GroupAddrStyle) *Config {
+	idx := ets.NewGroupAddressIndex(proj)
+	resolver := ets.NewComObjectResolver(manufacturers)
+
+	config := &Config{
+		Bridge: Bridge{
+			ID:     "bridge",
+			Label:  "KNX/IP Gateway",
+			Params: []Param{{Key: "type", Value: "TUNNEL"}},
+		},
+	}
+
+	for i := range proj.Installations {
+		proj.Installations[i].WalkDevices(func(area *ets.Area, line *ets.Line, device *ets.DeviceInstance) {
+			thing := Thing{ID: "device_" + sanitizeID(string(device.ID)), Label: device.Name}
+
+			if device.HasAddress {
+				address := ets.DeviceAddr(area, line, device).String()
+				thing.ID = "device_" + sanitizeID(address)
+				thing.Params = append(thing.Params, Param{Key: "address", Value: address})
+			}
+
+			if thing.Label == "" {
+				thing.Label = thing.ID
+			}
+
+			for _, comObj := range resolver.ResolveDevice(device) {
+				if len(comObj.Connectors) == 0 {
+					continue
+				}
+
+				dpt, ok := datapointTypeOf(&comObj, idx)
+				if !ok {
+					config.Unmapped = append(config.Unmapped, comObj)
+					continue
+				}
+
+				chType, ok := channelTypes[dpt.String()]
+				if !ok {
+					chType, ok = channelTypes[dpt.MainType().String()]
+				}
+
+				ga := formatGroupAddresses(&comObj, dpt, idx, style)
+				if !ok || ga == "" {
+					config.Unmapped = append(config.Unmapped, comObj)
+					continue
+				}
+
+				thing.Channels = append(thing.Channels, Channel{
+					ID:       sanitizeID(string(comObj.Instance.RefID)),
+					Label:    channelLabel(&comObj),
+					Type:     chType.channel,
+					ItemType: chType.itemType,
+					Params:   []Param{{Key: chType.param, Value: ga}},
+				})
+			}
+
+			if len(thing.Channels) > 0 {
+				config.Things = append(config.Things, thing)
+			}
+		})
+	}
+
+	return config
+}
@@ -0,0 +1,149 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package openhab
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/vapourismo/ets-go/ets"
+)
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+// testProject contains an actuator with switching, dimming and temperature objects as well as
+// objects that cannot be mapped, and a sensor without an individual address.
+var testProject = ets.Project{
+	Installations: []ets.Installation{{
+		Topology: []ets.Area{{
+			Address: 1,
+			Lines: []ets.Line{{
+				Address: 1,
+				Devices: []ets.DeviceInstance{
+					{
+						ID:         "P-0001-0_DI-1",
+						Name:       "Kitchen actuator",
+						Address:    1,
+						HasAddress: true,
+						ComObjects: []ets.ComObjectInstanceRef{
+							{
+								// Sends to its main group address and reads the status on start.
+								RefID:          "O-1_R-1",
+								Text:           stringPtr("Switch"),
+								ReadOnInitFlag: boolPtr(true),
+								Connectors: []ets.Connector{
+									{RefID: "GA-1"},
+									{RefID: "GA-2", Receive: true},
+								},
+							},
+							{
+								RefID:      "O-2_R-2",
+								Text:       stringPtr("Brightness"),
+								Connectors: []ets.Connector{{RefID: "GA-3"}},
+							},
+							{
+								// Only listens and has no sub type.
+								RefID:          "O-3_R-3",
+								FunctionText:   stringPtr("Temperature"),
+								DatapointType:  "DPT-9",
+								ReadOnInitFlag: boolPtr(true),
+								Connectors:     []ets.Connector{{RefID: "GA-4", Receive: true}},
+							},
+							{
+								RefID: "O-4_R-4",
+								Text:  stringPtr("Unconnected"),
+							},
+							{
+								RefID:      "O-5_R-5",
+								Text:       stringPtr("HVAC mode"),
+								Connectors: []ets.Connector{{RefID: "GA-5"}},
+							},
+							{
+								RefID:         "O-6_R-6",
+								Text:          stringPtr("Missing group address"),
+								DatapointType: "DPST-1-1",
+								Connectors:    []ets.Connector{{RefID: "GA-9"}},
+							},
+						},
+					},
+					{
+						ID:   "P-0001-0_DI-2",
+						Name: "Blind sensor",
+						ComObjects: []ets.ComObjectInstanceRef{{
+							RefID:      "O-1_R-1",
+							Connectors: []ets.Connector{{RefID: "GA-6"}},
+						}},
+					},
+				},
+			}},
+		}},
+		GroupAddresses: []ets.GroupRange{{
+			ID: "GR-1",
+			Addresses: []ets.GroupAddress{
+				{ID: "GA-1", Address: ets.NewGroupAddr3(1, 0, 1), DatapointType: "DPST-1-1"},
+				{ID: "GA-2", Address: ets.NewGroupAddr3(1, 0, 2), DatapointType: "DPST-1-1"},
+				{ID: "GA-3", Address: ets.NewGroupAddr3(1, 0, 3), DatapointType: "DPST-5-1"},
+				{ID: "GA-4", Address: ets.NewGroupAddr3(1, 0, 4)},
+				{ID: "GA-5", Address: ets.NewGroupAddr3(1, 0, 5), DatapointType: "DPST-20-102"},
+				{ID: "GA-6", Address: ets.NewGroupAddr3(1, 0, 6), DatapointType: "DPST-1-8"},
+			},
+		}},
+	}},
+}
+
+// checkGolden compares the output with the contents of the file in testdata.
+func checkGolden(t *testing.T, name string, got []byte) {
+	want, err := ioutil.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(got, want) {
+		t.Errorf("Got %s:\n%s\nwant:\n%s", name, got, want)
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	config := Generate(&testProject, nil, ets.GroupAddrStyleThreeLevel)
+	config.Bridge.Params = append(config.Bridge.Params, Param{Key: "ipAddress", Value: "192.168.0.10"})
+
+	var things bytes.Buffer
+	if err := config.WriteThings(&things); err != nil {
+		t.Fatal(err)
+	}
+
+	checkGolden(t, "test.things", things.Bytes())
+
+	var items bytes.Buffer
+	if err := config.WriteItems(&items); err != nil {
+		t.Fatal(err)
+	}
+
+	checkGolden(t, "test.items", items.Bytes())
+
+	var unmapped []ets.ComObjectRefID
+	for _, comObj := range config.Unmapped {
+		unmapped = append(unmapped, comObj.Instance.RefID)
+	}
+
+	if len(unmapped) != 2 || unmapped[0] != "O-5_R-5" || unmapped[1] != "O-6_R-6" {
+		t.Errorf("Got unmapped communication objects %v", unmapped)
+	}
+}
+
+func TestFormatGroupAddressesStyle(t *testing.T) {
+	config := Generate(&testProject, nil, ets.GroupAddrStyleTwoLevel)
+
+	want := "1.001:1/1+<1/2"
+	if got := config.Things[0].Channels[0].Params[0].Value; got != want {
+		t.Errorf("Got group addresses %q, want %q", got, want)
+	}
+}
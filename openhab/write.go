@@ -0,0 +1,96 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package openhab
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+func formatParams(params []Param) string {
+	if len(params) == 0 {
+		return "[ ]"
+	}
+
+	formatted := make([]string, len(params))
+	for n, param := range params {
+		formatted[n] = param.Key + "=" + strconv.Quote(param.Value)
+	}
+
+	return "[ " + strings.Join(formatted, ", ") + " ]"
+}
+
+// WriteThings writes the bridge and its things in the format of a .things file.
+func (c *Config) WriteThings(w io.Writer) error {
+	_, err := fmt.Fprintf(
+		w,
+		"Bridge knx:ip:%s %s %s {\n",
+		c.Bridge.ID,
+		strconv.Quote(c.Bridge.Label),
+		formatParams(c.Bridge.Params),
+	)
+	if err != nil {
+		return err
+	}
+
+	for _, thing := range c.Things {
+		_, err := fmt.Fprintf(
+			w,
+			"    Thing device %s %s %s {\n",
+			thing.ID,
+			strconv.Quote(thing.Label),
+			formatParams(thing.Params),
+		)
+		if err != nil {
+			return err
+		}
+
+		for _, channel := range thing.Channels {
+			_, err := fmt.Fprintf(
+				w,
+				"        Type %s : %s %s %s\n",
+				channel.Type,
+				channel.ID,
+				strconv.Quote(channel.Label),
+				formatParams(channel.Params),
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		if _, err := fmt.Fprintln(w, "    }"); err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintln(w, "}")
+	return err
+}
+
+// WriteItems writes one item per channel in the format of an .items file.
+func (c *Config) WriteItems(w io.Writer) error {
+	for _, thing := range c.Things {
+		for _, channel := range thing.Channels {
+			_, err := fmt.Fprintf(
+				w,
+				"%s %s_%s %s { channel=\"knx:device:%s:%s:%s\" }\n",
+				channel.ItemType,
+				thing.ID,
+				channel.ID,
+				strconv.Quote(thing.Label+" "+channel.Label),
+				c.Bridge.ID,
+				thing.ID,
+				channel.ID,
+			)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}